@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+
+	"github.com/develpudu/billctl/internal/batch"
+	"github.com/develpudu/billctl/internal/calculator"
+
+	"github.com/spf13/cobra"
+)
+
+// Batch command flags
+var batchWorkers int
+
+var batchCmd = &cobra.Command{
+	Use:   "batch FILE",
+	Short: "Calculate every job of a CSV or JSON file",
+	Long: `Calculate every row of a jobs file in one run. FILE is a CSV or JSON
+file, or - to read from standard input.
+
+CSV files need a header row with any of these columns:
+  client (or profile), period, from, to, hours, days, weeks, currency
+
+JSON files hold an array of jobs (or an object with a "jobs" array) using
+the same field names. A period accepts every -m format; separate several
+periods with ';'. Rows without a currency use their profile's default.
+
+Rows that fail are reported and do not stop the batch; the command exits
+with an error when any row failed, with the exit code of the rows' error
+class when they all share one.
+
+Examples:
+  billctl batch jobs.csv
+  billctl batch jobs.json -o csv > invoices.csv
+  cat jobs.csv | billctl batch - --workers 8`,
+	Args: usageArgs(cobra.ExactArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := calculator.ValidateOutput(outputMode); err != nil {
+			return err
+		}
+
+		jobs, err := readJobs(args[0])
+		if err != nil {
+			return err
+		}
+
+		file, err := loadConfigFile()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		clock, err := buildClock()
+		if err != nil {
+			return err
+		}
+		check, err := policyCheck()
+		if err != nil {
+			return fmt.Errorf("policy error: %v", err)
+		}
+
+		runner := &batch.Runner{
+			Profile: profileResolver(file),
+			Clock:   clock,
+			Workers: batchWorkers,
+			Check:   check,
+		}
+		results := runner.Run(jobs)
+
+		output, err := batch.Format(results, outputMode)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+
+		if err := batch.Err(results); err != nil {
+			// Row errors are already part of the output
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+			return err
+		}
+		return nil
+	},
+}
+
+// readJobs loads the jobs of a batch file, or of standard input for "-"
+func readJobs(path string) ([]batch.Job, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open batch file: %v", err)
+		}
+		defer f.Close()
+		r = f
+	}
+	return batch.Load(r)
+}
+
+func init() {
+	batchCmd.Flags().IntVar(&batchWorkers, "workers", runtime.NumCPU(), "Number of rows calculated concurrently")
+}
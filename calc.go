@@ -0,0 +1,38 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var calcCmd = &cobra.Command{
+	Use:   "calc",
+	Short: "Calculate a billing amount",
+	Long: `Calculate the amount billed for hours, days, weeks, months and date
+ranges, plus line items and reimbursable expenses. Every kind of input can
+be combined and repeated.
+
+Examples:
+  billctl calc -h 120                  # 120 hours
+  billctl calc -m 2024-02 -d 5         # February 2024 + 5 days
+  billctl calc -s 2 -d 3 -h 4          # 2 weeks + 3 days + 4 hours
+  billctl calc --from 2026-09-12 --to 2026-10-11
+  billctl calc -h 20 --fixed "Setup:500" --currency=EUR
+  billctl calc --project web -d 3 --project app -h 12
+  billctl calc --person ana -d 10 --person luis -h 32
+
+Input after each --project is billed to that project of the profile, at
+its own rate, with a subtotal per project. Input after each --person is
+billed as work of that team member, at their rate and schedule, with a
+subtotal per person.
+
+-h is --hours; use -? for help. "billctl -h 120" without the subcommand
+still works.`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCalc(cmd)
+	},
+}
+
+func init() {
+	addCalcFlags(calcCmd)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// recentMonths is the number of months suggested for -m
+const recentMonths = 12
+
+// currencyCompletions lists the ISO codes suggested for --currency
+var currencyCompletions = []string{
+	"USD\tDólar estadounidense",
+	"EUR\tEuro",
+	"ARS\tPeso argentino",
+	"BRL\tReal brasileño",
+	"CLP\tPeso chileno",
+	"UYU\tPeso uruguayo",
+	"MXN\tPeso mexicano",
+	"COP\tPeso colombiano",
+	"PEN\tSol peruano",
+	"GBP\tLibra esterlina",
+	"CAD\tDólar canadiense",
+	"CHF\tFranco suizo",
+	"JPY\tYen japonés",
+}
+
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate the shell completion script",
+	Long: `Generate the completion script for your shell. Besides commands and flags,
+it completes recent months for -m, ISO codes for --currency and the
+configured profiles for --client.
+
+  bash:        source <(billctl completion bash)
+  zsh:         billctl completion zsh > "${fpath[1]}/_billctl"
+  fish:        billctl completion fish | source
+  powershell:  billctl completion powershell | Out-String | Invoke-Expression`,
+	ValidArgs: []string{"bash", "zsh", "fish", "powershell"},
+	Args:      usageArgs(cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return rootCmd.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return rootCmd.GenZshCompletion(os.Stdout)
+		case "fish":
+			return rootCmd.GenFishCompletion(os.Stdout, true)
+		default:
+			return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
+
+// completeMonths suggests the most recent months in YYYY-MM format, newest
+// first, resolved against --today and --tz
+func completeMonths(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	clock, err := buildClock()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	now := clock.Now()
+	first := now.AddDate(0, 0, 1-now.Day())
+
+	var suggestions []string
+	for i := 0; i < recentMonths; i++ {
+		month := first.AddDate(0, -i, 0).Format("2006-01")
+		if strings.HasPrefix(month, toComplete) {
+			suggestions = append(suggestions, month)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveKeepOrder
+}
+
+// completeCurrencies suggests ISO currency codes
+func completeCurrencies(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var suggestions []string
+	for _, currency := range currencyCompletions {
+		if strings.HasPrefix(currency, strings.ToUpper(toComplete)) {
+			suggestions = append(suggestions, currency)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeClients suggests the profiles of the config file
+func completeClients(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	file, err := loadConfigFile()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	if file == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var suggestions []string
+	for _, name := range file.ProfileNames() {
+		if strings.HasPrefix(name, toComplete) {
+			suggestions = append(suggestions, name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjects suggests the projects of the selected profile
+func completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var suggestions []string
+	for name := range cfg.Projects {
+		if strings.HasPrefix(name, toComplete) {
+			suggestions = append(suggestions, name)
+		}
+	}
+	sort.Strings(suggestions)
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTeam suggests the team members of the selected profile, with
+// their roles
+func completeTeam(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+
+	var suggestions []string
+	for name, member := range cfg.Team {
+		if !strings.HasPrefix(name, toComplete) {
+			continue
+		}
+		if member.Role != "" {
+			name += "\t" + member.Role
+		}
+		suggestions = append(suggestions, name)
+	}
+	sort.Strings(suggestions)
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeNames suggests a fixed set of values
+func completeNames(names ...string) func(*cobra.Command, []string, string) ([]string, cobra.ShellCompDirective) {
+	return cobra.FixedCompletions(names, cobra.ShellCompDirectiveNoFileComp)
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Show the resolved billing configuration",
+	Long: `Show where the configuration comes from (--config, $BILLCTL_CONFIG or
+the default location), the profile in use and its settings after applying
+--client, --rate, --weeks-per-month and --fx.
+
+Examples:
+  billctl config
+  billctl config --client acme -o json`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, problems, err := resolveConfig()
+		if err != nil {
+			return err
+		}
+		if len(problems) > 0 {
+			return &calculator.ValidationError{Errors: problems}
+		}
+
+		report := struct {
+			File     string                `json:"file,omitempty"`
+			Source   string                `json:"source,omitempty"`
+			Profile  string                `json:"profile,omitempty"`
+			Profiles []string              `json:"profiles,omitempty"`
+			Config   *config.BillingConfig `json:"config"`
+		}{Profile: activeProfile, Config: cfg}
+		file, err := loadConfigFile()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		if file != nil {
+			report.File, report.Source = configFilePath()
+			report.Profiles = file.ProfileNames()
+		}
+
+		if outputMode == calculator.OutputJSON {
+			output, err := calculator.FormatJSON(report)
+			if err != nil {
+				return err
+			}
+			fmt.Print(output)
+			return nil
+		}
+
+		fmt.Println("=== CONFIGURACIÓN ===")
+		fmt.Println()
+		if file == nil {
+			fmt.Println("Archivo: ninguno (valores por defecto)")
+		} else {
+			fmt.Printf("Archivo: %s (%s)\n", report.File, report.Source)
+			fmt.Printf("Perfil: %s\n", profileLabel(report.Profile))
+			fmt.Printf("Perfiles disponibles: %s\n", strings.Join(report.Profiles, ", "))
+		}
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode configuration: %v", err)
+		}
+		fmt.Printf("\n%s\n", data)
+		return nil
+	},
+}
+
+// profileLabel names a profile for display
+func profileLabel(profile string) string {
+	if profile == "" {
+		return "predeterminado"
+	}
+	return profile
+}
+
+func init() {
+	addProfileFlags(configCmd)
+}
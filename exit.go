@@ -0,0 +1,193 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/develpudu/billctl/internal/batch"
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/internal/policy"
+
+	"github.com/spf13/cobra"
+)
+
+// Exit codes, one per error class
+const (
+	exitError  = 1 // unexpected failures (I/O, batch rows of mixed classes...)
+	exitUsage  = 2 // unknown flags, commands or arguments
+	exitInput  = 3 // invalid time, items or expenses
+	exitConfig = 4 // invalid configuration or profile
+	exitPolicy = 5 // billing policy violations
+)
+
+// usageError marks errors in how the command was invoked
+type usageError struct {
+	err error
+}
+
+// Error returns the message of the underlying error
+func (e usageError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e usageError) Unwrap() error {
+	return e.err
+}
+
+// usageArgs marks argument validation errors as usage errors
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			silenceUsageForJSON(cmd)
+			return usageError{err}
+		}
+		return nil
+	}
+}
+
+// silenceUsageForJSON keeps the usage text out of the JSON error envelope
+func silenceUsageForJSON(cmd *cobra.Command) {
+	if outputMode == calculator.OutputJSON {
+		cmd.SilenceUsage = true
+	}
+}
+
+// occurrence records which occurrence of its flag an input error came from,
+// counting from the zero-based index i
+func occurrence(err error, i int) error {
+	var inputErr *calculator.InputError
+	if errors.As(err, &inputErr) {
+		inputErr.Index = i + 1
+	}
+	return err
+}
+
+// errorEnvelope is the JSON written to stderr for failures with --output json
+type errorEnvelope struct {
+	Error errorDetail `json:"error"`
+}
+
+// errorDetail describes a failure for scripts
+type errorDetail struct {
+	Code       string             `json:"code"`
+	Message    string             `json:"message"`
+	Field      string             `json:"field,omitempty"`
+	Value      string             `json:"value,omitempty"`
+	Position   int                `json:"position,omitempty"`
+	ExitCode   int                `json:"exit_code"`
+	Violations []policy.Violation `json:"violations,omitempty"`
+	Errors     []errorDetail      `json:"errors,omitempty"`
+}
+
+// describeError classifies an error into its code, exit code and details
+func describeError(err error) errorDetail {
+	var rowsErr *batch.RowsError
+	if errors.As(err, &rowsErr) {
+		return describeRows(rowsErr)
+	}
+
+	var validationErr *calculator.ValidationError
+	if errors.As(err, &validationErr) {
+		if len(validationErr.Errors) == 1 {
+			return describeError(validationErr.Errors[0])
+		}
+		return describeProblems(validationErr)
+	}
+
+	detail := errorDetail{Code: "error", Message: err.Error(), ExitCode: exitError}
+
+	var inputErr *calculator.InputError
+	var fieldErr *config.FieldError
+	var violationErr *policy.ViolationError
+	var usageErr usageError
+	switch {
+	case errors.As(err, &usageErr):
+		detail.Code, detail.ExitCode = "usage", exitUsage
+	case errors.As(err, &violationErr):
+		detail.Code, detail.ExitCode = "policy_violation", exitPolicy
+		detail.Violations = violationErr.Violations
+	case errors.As(err, &fieldErr):
+		detail.Code, detail.ExitCode = "invalid_config", exitConfig
+		detail.Field, detail.Value = fieldErr.Field, fieldErr.Value
+	case errors.As(err, &inputErr):
+		detail.ExitCode = exitInput
+		detail.Field, detail.Value, detail.Position = inputErr.Field, inputErr.Value, inputErr.Index
+		switch {
+		case errors.Is(err, calculator.ErrInvalidMonth):
+			detail.Code = "invalid_month"
+		case errors.Is(err, calculator.ErrNegativeQuantity):
+			detail.Code = "negative_quantity"
+		default:
+			detail.Code = "invalid_input"
+		}
+	}
+	return detail
+}
+
+// describeProblems describes every problem of a validation error. Any
+// configuration problem makes it a configuration error.
+func describeProblems(err *calculator.ValidationError) errorDetail {
+	detail := errorDetail{Code: "validation_failed", Message: err.Error(), ExitCode: exitInput}
+	for _, problem := range err.Errors {
+		problemDetail := describeError(problem)
+		if problemDetail.ExitCode == exitConfig {
+			detail.ExitCode = exitConfig
+		}
+		detail.Errors = append(detail.Errors, problemDetail)
+	}
+	return detail
+}
+
+// describeRows describes a batch with failing rows. It exits with the class
+// of the failing rows when they all share one.
+func describeRows(err *batch.RowsError) errorDetail {
+	detail := errorDetail{Code: "batch_failed", Message: err.Error(), ExitCode: exitError}
+	for i, rowErr := range err.Errors {
+		exitCode := describeError(rowErr).ExitCode
+		if i > 0 && exitCode != detail.ExitCode {
+			detail.ExitCode = exitError
+			break
+		}
+		detail.ExitCode = exitCode
+	}
+	return detail
+}
+
+// problemLabel names where a problem was given, as the flag and its
+// occurrence when known
+func problemLabel(detail errorDetail) string {
+	label := detail.Field
+	if rootCmd.Flags().Lookup(label) != nil {
+		label = "--" + label
+	}
+	if detail.Position > 0 {
+		label = fmt.Sprintf("%s #%d", label, detail.Position)
+	}
+	return label
+}
+
+// reportError writes an error to w, as a JSON envelope with --output json,
+// and returns the exit code of its class
+func reportError(w io.Writer, err error) int {
+	detail := describeError(err)
+	if outputMode == calculator.OutputJSON {
+		data, _ := json.Marshal(errorEnvelope{Error: detail})
+		fmt.Fprintln(w, string(data))
+	} else if len(detail.Errors) > 0 {
+		fmt.Fprintf(w, "Error: %d validation errors:\n", len(detail.Errors))
+		for _, problem := range detail.Errors {
+			if label := problemLabel(problem); label != "" {
+				fmt.Fprintf(w, "  %s: %s\n", label, problem.Message)
+			} else {
+				fmt.Fprintf(w, "  %s\n", problem.Message)
+			}
+		}
+	} else {
+		fmt.Fprintf(w, "Error: %v\n", err)
+	}
+	return detail.ExitCode
+}
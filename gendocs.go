@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/cobra/doc"
+)
+
+// Documentation formats
+const (
+	docsFormatMan      = "man"
+	docsFormatMarkdown = "markdown"
+)
+
+// Documentation command flags
+var (
+	docsFormat string
+	docsDir    string
+)
+
+var genDocsCmd = &cobra.Command{
+	Use:    "gen-docs",
+	Short:  "Generate man pages or Markdown docs from the command tree",
+	Hidden: true,
+	Args:   usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := os.MkdirAll(docsDir, 0o755); err != nil {
+			return fmt.Errorf("failed to create docs directory: %v", err)
+		}
+		clock, err := buildClock()
+		if err != nil {
+			return err
+		}
+		if err := genDocs(docsFormat, docsDir, clock.Now()); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Documentación generada en %s\n", docsDir)
+		return nil
+	},
+}
+
+// genDocs writes one page per command of the tree to dir. Man pages are
+// dated today; --today makes them reproducible.
+func genDocs(format, dir string, today time.Time) error {
+	rootCmd.DisableAutoGenTag = true
+	switch format {
+	case docsFormatMan:
+		return doc.GenManTree(rootCmd, &doc.GenManHeader{
+			Title:   "BILLCTL",
+			Section: "1",
+			Source:  "billctl " + Version,
+			Manual:  "Billctl Manual",
+			Date:    &today,
+		}, dir)
+	case docsFormatMarkdown:
+		return doc.GenMarkdownTree(rootCmd, dir)
+	default:
+		return usageError{fmt.Errorf("invalid docs format: %s (use man or markdown)", format)}
+	}
+}
+
+func init() {
+	genDocsCmd.Flags().StringVar(&docsFormat, "format", docsFormatMarkdown, "Docs format: man or markdown")
+	genDocsCmd.Flags().StringVar(&docsDir, "dir", "./docs", "Directory the pages are written to")
+	genDocsCmd.RegisterFlagCompletionFunc("format", completeNames(docsFormatMan, docsFormatMarkdown))
+}
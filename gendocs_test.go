@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func TestGenDocs(t *testing.T) {
+	tests := []struct {
+		format string
+		page   func(cmd *cobra.Command) string
+	}{
+		{docsFormatMarkdown, func(cmd *cobra.Command) string {
+			return strings.ReplaceAll(cmd.CommandPath(), " ", "_") + ".md"
+		}},
+		{docsFormatMan, func(cmd *cobra.Command) string {
+			return strings.ReplaceAll(cmd.CommandPath(), " ", "-") + ".1"
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := genDocs(tt.format, dir, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)); err != nil {
+				t.Fatalf("genDocs() unexpected error: %v", err)
+			}
+
+			for _, cmd := range documentedCommands(rootCmd) {
+				data, err := os.ReadFile(filepath.Join(dir, tt.page(cmd)))
+				if err != nil {
+					t.Errorf("genDocs() is missing the page of %q: %v", cmd.CommandPath(), err)
+					continue
+				}
+				page := string(data)
+				cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+					if !flag.Hidden && !strings.Contains(page, "--"+flag.Name) {
+						t.Errorf("page of %q does not document --%s", cmd.CommandPath(), flag.Name)
+					}
+				})
+				cmd.InheritedFlags().VisitAll(func(flag *pflag.Flag) {
+					if !flag.Hidden && !strings.Contains(page, "--"+flag.Name) {
+						t.Errorf("page of %q does not document inherited --%s", cmd.CommandPath(), flag.Name)
+					}
+				})
+			}
+
+			if _, err := os.Stat(filepath.Join(dir, tt.page(genDocsCmd))); err == nil {
+				t.Error("genDocs() documented the hidden gen-docs command")
+			}
+		})
+	}
+
+	if err := genDocs("html", t.TempDir(), time.Now()); err == nil {
+		t.Error("genDocs(html) expected an error")
+	}
+}
+
+// documentedCommands returns cmd and every visible command below it
+func documentedCommands(cmd *cobra.Command) []*cobra.Command {
+	commands := []*cobra.Command{cmd}
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() && !sub.IsAdditionalHelpTopicCommand() {
+			commands = append(commands, documentedCommands(sub)...)
+		}
+	}
+	return commands
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/develpudu/billctl/internal/rpc"
+	"github.com/develpudu/billctl/internal/rpc/billingpb"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// gRPC command flags
+var grpcAddr string
+
+var grpcCmd = &cobra.Command{
+	Use:   "grpc",
+	Short: "Serve the calculator as a gRPC service",
+	Long: `Expose the calculator as the billctl.v1.BillingService gRPC service
+defined in proto/billctl/v1/billing.proto:
+
+  Calculate         Calculate a billing amount
+  GetRates          Rate table of a profile
+  GetMonthSummary   Hours and amount of a month
+  CalculateBatch    Calculate a stream of jobs, answering each in order
+
+Profiles are read from the same config file as the CLI.`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := loadConfigFile()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		clock, err := buildClock()
+		if err != nil {
+			return err
+		}
+		check, err := policyCheck()
+		if err != nil {
+			return fmt.Errorf("policy error: %v", err)
+		}
+
+		listener, err := net.Listen("tcp", grpcAddr)
+		if err != nil {
+			return err
+		}
+
+		grpcServer := grpc.NewServer()
+		billingpb.RegisterBillingServiceServer(grpcServer, &rpc.Server{
+			Profile: profileResolver(file),
+			Clock:   clock,
+			Check:   check,
+		})
+
+		signals := make(chan os.Signal, 1)
+		signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+		go func() {
+			<-signals
+			grpcServer.GracefulStop()
+		}()
+
+		fmt.Fprintf(os.Stderr, "Listening on %s\n", grpcAddr)
+		return grpcServer.Serve(listener)
+	},
+}
+
+func init() {
+	grpcCmd.Flags().StringVar(&grpcAddr, "addr", ":9090", "Address to listen on")
+}
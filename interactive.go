@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/internal/interactive"
+
+	"github.com/spf13/cobra"
+)
+
+var interactiveCmd = &cobra.Command{
+	Use:   "interactive",
+	Short: "Calculate step by step with guided prompts",
+	Long: `Prompt for the client, periods, extra days and extra hours instead of
+flags. The breakdown is redrawn after every answer. When done, the result
+can be exported as text, JSON or CSV, or saved as an invoice.`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := loadConfigFile()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		clock, err := buildClock()
+		if err != nil {
+			return err
+		}
+		check, err := policyCheck()
+		if err != nil {
+			return fmt.Errorf("policy error: %v", err)
+		}
+
+		session := &interactive.Session{
+			In:      os.Stdin,
+			Out:     os.Stdout,
+			Profile: profileResolver(file),
+			Clock:   clock,
+			Save: func(path string, data []byte) error {
+				return os.WriteFile(path, data, 0o644)
+			},
+			Clear: isTerminal(os.Stdout),
+			Check: check,
+		}
+		if file != nil {
+			session.Profiles = file.ProfileNames()
+		}
+		return session.Run()
+	},
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// profileResolver builds the configuration of a named profile, falling back
+// to the defaults when there is no config file
+func profileResolver(file *config.File) func(name string) (*config.BillingConfig, error) {
+	return func(name string) (*config.BillingConfig, error) {
+		if file == nil {
+			if name != "" {
+				return nil, &config.FieldError{Field: "profile", Value: name, Err: fmt.Errorf("%w: %s (no config file found)", config.ErrUnknownProfile, name)}
+			}
+			return config.NewBillingConfig(), nil
+		}
+		return file.Profile(name)
+	}
+}
@@ -0,0 +1,447 @@
+package batch
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/pkg/billing"
+)
+
+// Job is one row of a batch file
+type Job struct {
+	Row      int     `json:"-"`
+	Client   string  `json:"client,omitempty"`
+	Profile  string  `json:"profile,omitempty"` // alias of client
+	Period   string  `json:"period"`            // periods accepted by -m, separated by ';'
+	From     string  `json:"from,omitempty"`
+	To       string  `json:"to,omitempty"`
+	Hours    float64 `json:"hours"`
+	Days     int     `json:"days"`
+	Weeks    int     `json:"weeks"`
+	Currency string  `json:"currency,omitempty"`
+
+	err error // parse error reported for the row
+}
+
+// Input builds the billing input of the job
+func (j Job) Input() (billing.Input, error) {
+	input := billing.Input{From: j.From, To: j.To, Currency: j.Currency}
+
+	for _, period := range strings.Split(j.Period, ";") {
+		if period = strings.TrimSpace(period); period != "" {
+			input.Months = append(input.Months, period)
+		}
+	}
+	if j.Hours != 0 {
+		input.Hours = []float64{j.Hours}
+	}
+	if j.Days != 0 {
+		input.Days = []int{j.Days}
+	}
+	if j.Weeks != 0 {
+		input.Weeks = []int{j.Weeks}
+	}
+
+	if len(input.Months) == 0 && len(input.Hours) == 0 && len(input.Days) == 0 &&
+		len(input.Weeks) == 0 && input.From == "" && input.To == "" {
+		return input, fmt.Errorf("no period or quantities given")
+	}
+	return input, nil
+}
+
+// Load reads jobs from CSV or JSON, detected from the first character of
+// the input. Rows that cannot be parsed are kept and reported as errors
+// when the batch runs.
+func Load(r io.Reader) ([]Job, error) {
+	reader := bufio.NewReader(r)
+	for {
+		b, err := reader.Peek(1)
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("batch file is empty")
+			}
+			return nil, fmt.Errorf("failed to read batch file: %v", err)
+		}
+		switch b[0] {
+		case ' ', '\t', '\r', '\n':
+			reader.ReadByte()
+			continue
+		case '[', '{':
+			return ParseJSON(reader)
+		default:
+			return ParseCSV(reader)
+		}
+	}
+}
+
+// ParseJSON reads jobs from a JSON array or from an object with a jobs array
+func ParseJSON(r io.Reader) ([]Job, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch file: %v", err)
+	}
+
+	var rows []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapper struct {
+			Jobs []json.RawMessage `json:"jobs"`
+		}
+		if err := json.Unmarshal(data, &wrapper); err != nil {
+			return nil, fmt.Errorf("failed to parse batch file: %v", err)
+		}
+		rows = wrapper.Jobs
+	} else if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, fmt.Errorf("failed to parse batch file: %v", err)
+	}
+
+	jobs := make([]Job, 0, len(rows))
+	for i, raw := range rows {
+		var job Job
+		if err := json.Unmarshal(raw, &job); err != nil {
+			job = Job{err: fmt.Errorf("invalid job: %v", err)}
+		}
+		if job.Client == "" {
+			job.Client = job.Profile
+		}
+		job.Row = i + 1
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+// ParseCSV reads jobs from CSV with a header row. Recognized columns are
+// client (or profile), period, from, to, hours, days, weeks and currency.
+func ParseCSV(r io.Reader) ([]Job, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read batch header: %v", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["client"]; !ok {
+		if i, ok := columns["profile"]; ok {
+			columns["client"] = i
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var jobs []Job
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read batch file: %v", err)
+		}
+
+		job := Job{
+			Row:      row,
+			Client:   field(record, "client"),
+			Period:   field(record, "period"),
+			From:     field(record, "from"),
+			To:       field(record, "to"),
+			Currency: field(record, "currency"),
+		}
+		if value := field(record, "hours"); value != "" {
+			if job.Hours, err = strconv.ParseFloat(value, 64); err != nil {
+				job.err = fmt.Errorf("invalid hours: %s", value)
+			}
+		}
+		if value := field(record, "days"); value != "" && job.err == nil {
+			if job.Days, err = strconv.Atoi(value); err != nil {
+				job.err = fmt.Errorf("invalid days: %s", value)
+			}
+		}
+		if value := field(record, "weeks"); value != "" && job.err == nil {
+			if job.Weeks, err = strconv.Atoi(value); err != nil {
+				job.err = fmt.Errorf("invalid weeks: %s", value)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+
+	return jobs, nil
+}
+
+// Result is the outcome of one job
+type Result struct {
+	Job    Job             `json:"job"`
+	Result *billing.Result `json:"result,omitempty"`
+	Err    error           `json:"-"`
+
+	calc *billing.Calculator // formats the text breakdown of the row
+}
+
+// MarshalJSON reports the row error as a string
+func (r Result) MarshalJSON() ([]byte, error) {
+	type alias Result
+	out := struct {
+		Row int `json:"row"`
+		alias
+		Error string `json:"error,omitempty"`
+	}{Row: r.Job.Row, alias: alias(r)}
+	if r.Err != nil {
+		out.Error = r.Err.Error()
+	}
+	return json.Marshal(out)
+}
+
+// Runner calculates batch jobs concurrently
+type Runner struct {
+	Profile func(name string) (*config.BillingConfig, error) // resolves the profile of a row
+	Clock   billing.Clock
+	Workers int
+	Check   func(client string, cfg *config.BillingConfig, result *billing.Result) error // rejects results that break the billing policy
+}
+
+// Run calculates every job with a pool of workers and returns the results
+// in row order. Failing rows carry their error; they never stop the batch.
+func (r *Runner) Run(jobs []Job) []Result {
+	results := make([]Result, len(jobs))
+
+	workers := r.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				results[i] = r.runJob(jobs[i])
+			}
+		}()
+	}
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
+
+// runJob calculates a single job
+func (r *Runner) runJob(job Job) Result {
+	result := Result{Job: job, Err: job.err}
+	if result.Err != nil {
+		return result
+	}
+
+	cfg, err := r.Profile(job.Client)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	input, err := job.Input()
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	calc, err := billing.New(billing.WithConfig(cfg), billing.WithClock(r.Clock))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	result.Result, result.Err = calc.Calculate(context.Background(), input)
+	result.calc = calc
+	if result.Err == nil && r.Check != nil {
+		if err := r.Check(job.Client, cfg, result.Result); err != nil {
+			result.Result, result.Err = nil, err
+		}
+	}
+	return result
+}
+
+// Summary aggregates the results of a batch
+type Summary struct {
+	Rows       int                `json:"rows"`
+	Succeeded  int                `json:"succeeded"`
+	Failed     int                `json:"failed"`
+	TotalHours float64            `json:"total_hours"`
+	Totals     map[string]float64 `json:"totals"` // total amount per currency
+}
+
+// Summarize aggregates the results of a batch
+func Summarize(results []Result) Summary {
+	summary := Summary{Rows: len(results), Totals: map[string]float64{}}
+	for _, result := range results {
+		if result.Err != nil {
+			summary.Failed++
+			continue
+		}
+		summary.Succeeded++
+		summary.TotalHours += result.Result.BilledHours
+		summary.Totals[result.Result.Currency] += result.Result.TotalAmount
+	}
+	return summary
+}
+
+// RowsError reports a batch with failing rows. It matches the errors of
+// those rows with errors.Is and errors.As.
+type RowsError struct {
+	Rows   int     // rows in the batch
+	Errors []error // errors of the failing rows, in row order
+}
+
+// Error tells how many rows failed
+func (e *RowsError) Error() string {
+	return fmt.Sprintf("%d of %d rows failed", len(e.Errors), e.Rows)
+}
+
+// Unwrap returns the errors of the failing rows
+func (e *RowsError) Unwrap() []error {
+	return e.Errors
+}
+
+// Err returns a *RowsError when any row of the batch failed, or nil
+func Err(results []Result) error {
+	err := &RowsError{Rows: len(results)}
+	for _, result := range results {
+		if result.Err != nil {
+			err.Errors = append(err.Errors, result.Err)
+		}
+	}
+	if len(err.Errors) == 0 {
+		return nil
+	}
+	return err
+}
+
+// currencies returns the currencies of a summary in alphabetical order
+func (s Summary) currencies() []string {
+	currencies := make([]string, 0, len(s.Totals))
+	for currency := range s.Totals {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// label describes the job of a row for display
+func (j Job) label() string {
+	parts := []string{}
+	if j.Client != "" {
+		parts = append(parts, j.Client)
+	}
+	if j.Period != "" {
+		parts = append(parts, j.Period)
+	}
+	if j.From != "" || j.To != "" {
+		parts = append(parts, j.From+".."+j.To)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Format renders the results and their summary in the given output format
+func Format(results []Result, format string) (string, error) {
+	summary := Summarize(results)
+
+	switch format {
+	case calculator.OutputJSON:
+		return calculator.FormatJSON(struct {
+			Results []Result `json:"results"`
+			Summary Summary  `json:"summary"`
+		}{results, summary})
+	case calculator.OutputCSV:
+		return formatCSV(results, summary), nil
+	case calculator.OutputText:
+		return formatText(results, summary), nil
+	default:
+		return "", calculator.ValidateOutput(format)
+	}
+}
+
+// formatText renders every row breakdown followed by the batch summary
+func formatText(results []Result, summary Summary) string {
+	var output strings.Builder
+
+	for _, result := range results {
+		title := fmt.Sprintf("Fila %d", result.Job.Row)
+		if label := result.Job.label(); label != "" {
+			title += ": " + label
+		}
+		output.WriteString(fmt.Sprintf("##### %s #####\n", title))
+		if result.Err != nil {
+			output.WriteString(fmt.Sprintf("Error: %v\n\n", result.Err))
+			continue
+		}
+		breakdown, _ := result.calc.Format(result.Result, billing.FormatText) // only unknown formats fail
+		output.WriteString(breakdown)
+		output.WriteString("\n")
+	}
+
+	output.WriteString("=== RESUMEN DEL LOTE ===\n")
+	output.WriteString(fmt.Sprintf("  Filas procesadas: %d\n", summary.Rows))
+	output.WriteString(fmt.Sprintf("  Correctas: %d\n", summary.Succeeded))
+	output.WriteString(fmt.Sprintf("  Con errores: %d\n", summary.Failed))
+	output.WriteString(fmt.Sprintf("  Total de horas facturadas: %s\n",
+		strconv.FormatFloat(summary.TotalHours, 'f', -1, 64)))
+	for _, currency := range summary.currencies() {
+		output.WriteString(fmt.Sprintf("  TOTAL A FACTURAR: %s %.2f\n", currency, summary.Totals[currency]))
+	}
+
+	return output.String()
+}
+
+// formatCSV renders one row per job followed by a total row per currency
+func formatCSV(results []Result, summary Summary) string {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"row", "client", "period", "hours", "labor", "items", "expenses", "tax", "total", "currency", "error"})
+
+	amount := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, result := range results {
+		job := result.Job
+		row := []string{strconv.Itoa(job.Row), job.Client, job.Period}
+		if result.Err != nil {
+			row = append(row, "", "", "", "", "", "", job.Currency, result.Err.Error())
+		} else {
+			r := result.Result
+			row = append(row, strconv.FormatFloat(r.BilledHours, 'f', -1, 64), amount(r.LaborAmount),
+				amount(r.ItemsAmount), amount(r.ExpenseTotal), amount(r.LaborTax+r.ExpenseTax),
+				amount(r.TotalAmount), r.Currency, "")
+		}
+		writer.Write(row)
+	}
+
+	for _, currency := range summary.currencies() {
+		writer.Write([]string{"total", "", "", "", "", "", "", "", amount(summary.Totals[currency]), currency, ""})
+	}
+
+	writer.Flush()
+	return buf.String()
+}
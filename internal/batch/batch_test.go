@@ -0,0 +1,272 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/pkg/billing"
+)
+
+// testRunner resolves the default profile plus an hourly "acme" profile
+func testRunner(workers int) *Runner {
+	return &Runner{
+		Profile: func(name string) (*config.BillingConfig, error) {
+			cfg := config.NewBillingConfig()
+			switch name {
+			case "":
+			case "acme":
+				if err := cfg.SetRate(config.AnchorHourly, 25); err != nil {
+					return nil, err
+				}
+				cfg.DefaultCurrency = "EUR"
+			default:
+				return nil, fmt.Errorf("unknown profile: %s", name)
+			}
+			return cfg, nil
+		},
+		Clock:   calculator.FixedClock{Time: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		Workers: workers,
+	}
+}
+
+func TestLoadCSV(t *testing.T) {
+	input := `client,period,hours,days,weeks,currency
+acme,2026-09;2026-10,4,,,
+,2026-Q3,,2,1,EUR
+beta,,abc,,,
+`
+	jobs, err := Load(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	if len(jobs) != 3 {
+		t.Fatalf("Load() returned %d jobs, want 3", len(jobs))
+	}
+	if jobs[0].Client != "acme" || jobs[0].Period != "2026-09;2026-10" || jobs[0].Hours != 4 {
+		t.Errorf("jobs[0] = %+v, want acme 2026-09;2026-10 with 4 hours", jobs[0])
+	}
+	if jobs[1].Days != 2 || jobs[1].Weeks != 1 || jobs[1].Currency != "EUR" {
+		t.Errorf("jobs[1] = %+v, want 2 days, 1 week in EUR", jobs[1])
+	}
+	if jobs[2].err == nil {
+		t.Error("jobs[2] expected a parse error for invalid hours, got nil")
+	}
+	for i, job := range jobs {
+		if job.Row != i+1 {
+			t.Errorf("jobs[%d].Row = %d, want %d", i, job.Row, i+1)
+		}
+	}
+}
+
+func TestLoadJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"array", `[{"profile": "acme", "period": "2026-09"}, {"hours": "x"}]`},
+		{"object", ` {"jobs": [{"client": "acme", "period": "2026-09"}, {"hours": "x"}]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			jobs, err := Load(strings.NewReader(test.input))
+			if err != nil {
+				t.Fatalf("Load() unexpected error: %v", err)
+			}
+			if len(jobs) != 2 {
+				t.Fatalf("Load() returned %d jobs, want 2", len(jobs))
+			}
+			if jobs[0].Client != "acme" || jobs[0].Period != "2026-09" {
+				t.Errorf("jobs[0] = %+v, want acme 2026-09", jobs[0])
+			}
+			if jobs[1].err == nil || jobs[1].Row != 2 {
+				t.Errorf("jobs[1] = %+v, want a parse error on row 2", jobs[1])
+			}
+		})
+	}
+
+	if _, err := Load(strings.NewReader("[{")); err == nil {
+		t.Error("Load() expected error for malformed JSON, got nil")
+	}
+	if _, err := Load(strings.NewReader("  \n")); err == nil {
+		t.Error("Load() expected error for empty input, got nil")
+	}
+}
+
+func TestRunnerRun(t *testing.T) {
+	jobs := []Job{
+		{Row: 1, Client: "acme", Hours: 10},
+		{Row: 2, Period: "2026-09"},
+		{Row: 3, Client: "missing", Hours: 1},
+		{Row: 4, Period: "bogus"},
+		{Row: 5},
+		{Row: 6, Client: "acme", From: "2026-09-28", To: "2026-10-02", Currency: "USD"},
+	}
+
+	results := testRunner(3).Run(jobs)
+
+	if len(results) != len(jobs) {
+		t.Fatalf("Run() returned %d results, want %d", len(results), len(jobs))
+	}
+	for i, result := range results {
+		if result.Job.Row != i+1 {
+			t.Errorf("results[%d] is row %d, want row order", i, result.Job.Row)
+		}
+	}
+
+	if results[0].Err != nil || results[0].Result.TotalAmount != 250 || results[0].Result.Currency != "EUR" {
+		t.Errorf("row 1 = %+v, want EUR 250.00", results[0].Result)
+	}
+	if results[1].Err != nil || results[1].Result.TotalAmount != 3300 {
+		t.Errorf("row 2 = %+v, want U$S 3300.00", results[1].Result)
+	}
+	for _, i := range []int{2, 3, 4} {
+		if results[i].Err == nil {
+			t.Errorf("row %d expected error, got nil", i+1)
+		}
+	}
+	if results[5].Err != nil || results[5].Result.TotalAmount != 5*8*25 {
+		t.Errorf("row 6 = %+v (%v), want USD 1000.00", results[5].Result, results[5].Err)
+	}
+
+	summary := Summarize(results)
+	if summary.Rows != 6 || summary.Succeeded != 3 || summary.Failed != 3 {
+		t.Errorf("Summarize() = %+v, want 6 rows, 3 succeeded, 3 failed", summary)
+	}
+	if summary.Totals["EUR"] != 250 || summary.Totals["U$S"] != 3300 || summary.Totals["USD"] != 1000 {
+		t.Errorf("Summarize() totals = %v", summary.Totals)
+	}
+	if summary.TotalHours != 10+240+40 {
+		t.Errorf("Summarize() TotalHours = %g, want 290", summary.TotalHours)
+	}
+}
+
+func TestRunnerRunManyRows(t *testing.T) {
+	jobs := make([]Job, 200)
+	for i := range jobs {
+		jobs[i] = Job{Row: i + 1, Hours: float64(i + 1)}
+	}
+
+	results := testRunner(8).Run(jobs)
+	for i, result := range results {
+		if result.Err != nil || result.Result.TotalHours != float64(i+1) {
+			t.Fatalf("results[%d] = %+v, want %d hours", i, result.Result, i+1)
+		}
+	}
+}
+
+func TestRunnerRunCheck(t *testing.T) {
+	runner := testRunner(2)
+	runner.Check = func(client string, cfg *config.BillingConfig, result *billing.Result) error {
+		if result.HourlyRate < 20 {
+			return fmt.Errorf("hourly rate %.2f is below the minimum of 20.00", result.HourlyRate)
+		}
+		return nil
+	}
+
+	results := runner.Run([]Job{{Row: 1, Client: "acme", Hours: 4}, {Row: 2, Hours: 4}})
+	if results[0].Err != nil {
+		t.Errorf("results[0] unexpected error: %v", results[0].Err)
+	}
+	if results[1].Err == nil || results[1].Result != nil {
+		t.Errorf("results[1] = %+v, want a policy error and no result", results[1])
+	}
+}
+
+func TestErr(t *testing.T) {
+	results := testRunner(2).Run([]Job{{Row: 1, Hours: 4}, {Row: 2, Period: "2024-13"}, {Row: 3, Hours: -1}})
+
+	var rowsErr *RowsError
+	if err := Err(results); !errors.As(err, &rowsErr) {
+		t.Fatalf("Err() = %v, want a *RowsError", err)
+	}
+	if rowsErr.Rows != 3 || len(rowsErr.Errors) != 2 || rowsErr.Error() != "2 of 3 rows failed" {
+		t.Errorf("Err() = %+v (%q), want 2 of 3 rows failed", rowsErr, rowsErr.Error())
+	}
+	if !errors.Is(rowsErr, calculator.ErrInvalidMonth) || !errors.Is(rowsErr, calculator.ErrNegativeQuantity) {
+		t.Errorf("Err() = %v, want it to match the errors of the failing rows", rowsErr)
+	}
+
+	if err := Err(results[:1]); err != nil {
+		t.Errorf("Err() = %v, want nil without failing rows", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	results := testRunner(2).Run([]Job{
+		{Row: 1, Client: "acme", Period: "2026-09", Hours: 2},
+		{Row: 2, Period: "bogus"},
+	})
+
+	text, err := Format(results, calculator.OutputText)
+	if err != nil {
+		t.Fatalf("Format(text) unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"##### Fila 1: acme 2026-09 #####",
+		"=== CÁLCULO DE FACTURACIÓN ===",
+		"##### Fila 2: bogus #####\nError:",
+		"=== RESUMEN DEL LOTE ===",
+		"Con errores: 1",
+		"TOTAL A FACTURAR: EUR 6050.00",
+	} {
+		if !strings.Contains(text, expected) {
+			t.Errorf("Format(text) output missing expected substring: %s", expected)
+		}
+	}
+
+	csvOutput, err := Format(results, calculator.OutputCSV)
+	if err != nil {
+		t.Fatalf("Format(csv) unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csvOutput), "\n")
+	expectedLines := []string{
+		"row,client,period,hours,labor,items,expenses,tax,total,currency,error",
+		"1,acme,2026-09,242,6050.00,0.00,0.00,0.00,6050.00,EUR,",
+		"total,,,,,,,,6050.00,EUR,",
+	}
+	if len(lines) != 4 || lines[0] != expectedLines[0] || lines[1] != expectedLines[1] || lines[3] != expectedLines[2] {
+		t.Errorf("Format(csv) = %q", csvOutput)
+	}
+	if !strings.HasPrefix(lines[2], "2,,bogus,") {
+		t.Errorf("Format(csv) error row = %q", lines[2])
+	}
+
+	jsonOutput, err := Format(results, calculator.OutputJSON)
+	if err != nil {
+		t.Fatalf("Format(json) unexpected error: %v", err)
+	}
+	var decoded struct {
+		Results []struct {
+			Row    int `json:"row"`
+			Result *struct {
+				TotalAmount float64 `json:"total_amount"`
+			} `json:"result"`
+			Error string `json:"error"`
+		} `json:"results"`
+		Summary Summary `json:"summary"`
+	}
+	if err := json.Unmarshal([]byte(jsonOutput), &decoded); err != nil {
+		t.Fatalf("Format(json) produced invalid JSON: %v", err)
+	}
+	if decoded.Results[0].Row != 1 || decoded.Results[0].Result.TotalAmount != 6050 {
+		t.Errorf("Format(json) row 1 = %+v", decoded.Results[0])
+	}
+	if decoded.Results[1].Error == "" || decoded.Results[1].Result != nil {
+		t.Errorf("Format(json) row 2 = %+v, want only an error", decoded.Results[1])
+	}
+	if decoded.Summary.Failed != 1 {
+		t.Errorf("Format(json) summary = %+v, want 1 failed row", decoded.Summary)
+	}
+
+	if _, err := Format(results, "xml"); err == nil {
+		t.Error("Format(xml) expected error, got nil")
+	}
+}
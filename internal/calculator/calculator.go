@@ -2,50 +2,86 @@ package calculator
 
 import (
 	"fmt"
+	"log/slog"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
-	"billctl/internal/config"
+	"github.com/develpudu/billctl/internal/config"
 )
 
 // MonthInfo holds month calculation details
 type MonthInfo struct {
-	Input string
-	Days  int
-	Year  int
-	Month int
+	Input      string  `json:"input"`
+	Days       int     `json:"days"`
+	Year       int     `json:"year"`
+	Month      int     `json:"month"`
+	Hours      float64 `json:"hours"`
+	HourlyRate float64 `json:"hourly_rate"`
+	Amount     float64 `json:"amount"`
+	From       string  `json:"from,omitempty"`  // first billed date of a date range segment (YYYY-MM-DD)
+	To         string  `json:"to,omitempty"`    // last billed date of a date range segment
+	Since      string  `json:"since,omitempty"` // first date of a whole month billed in parts across a rate change
+	Until      string  `json:"until,omitempty"` // last date of that part
+	Project    string  `json:"project,omitempty"`
+	Person     string  `json:"person,omitempty"`
 }
 
 // TimeInput represents user input for time calculations
 type TimeInput struct {
-	Hours  []int
-	Days   []int
-	Weeks  []int
-	Months []string
+	Hours    []float64
+	Days     []int
+	Weeks    []int
+	Months   []string
+	Ranges   []DateRange
+	Items    []ItemInput
+	Expenses []ExpenseInput
+	Project  string // project the input is billed to (see CalculateProjects)
+	Person   string // team member who did the work (see CalculateProjects)
 }
 
 // CalculationResult holds the breakdown and total
 type CalculationResult struct {
-	MonthDetails []MonthInfo
-	TotalWeeks   int
-	TotalDays    int
-	TotalHours   int
-	TotalTime    int
-	TotalAmount  float64
-	Currency     string
+	MonthDetails  []MonthInfo      `json:"month_details,omitempty"`
+	TotalWeeks    int              `json:"total_weeks"`
+	TotalDays     int              `json:"total_days"`
+	TotalHours    float64          `json:"total_hours"`
+	TotalTime     float64          `json:"total_time"`
+	BilledHours   float64          `json:"billed_hours"`
+	Adjustments   []Adjustment     `json:"adjustments,omitempty"`
+	LineItems     []LineItem       `json:"line_items,omitempty"`
+	Expenses      []ExpenseLine    `json:"expenses,omitempty"`
+	LaborAmount   float64          `json:"labor_amount"`
+	ItemsAmount   float64          `json:"items_amount"`
+	ExpenseTotal  float64          `json:"expense_total"`
+	LaborTax      float64          `json:"labor_tax"`
+	ExpenseTax    float64          `json:"expense_tax"`
+	TotalAmount   float64          `json:"total_amount"`
+	Currency      string           `json:"currency"`
+	HourlyRate    float64          `json:"hourly_rate"`
+	WeeksStrategy string           `json:"weeks_strategy"`
+	WeeksPerMonth float64          `json:"weeks_per_month"`
+	Retainer      *RetainerSummary `json:"retainer,omitempty"`
+	Projects      []ProjectResult  `json:"projects,omitempty"`
+	People        []PersonResult   `json:"people,omitempty"`
+	Parts         []PartResult     `json:"parts,omitempty"`
 }
 
 // Calculator handles all billing calculations
 type Calculator struct {
-	config *config.BillingConfig
+	config   *config.BillingConfig
+	retainer *RetainerState
+	clock    Clock
+	logger   *slog.Logger
 }
 
-// NewCalculator creates a new calculator instance
+// NewCalculator creates a new calculator instance using the system clock
 func NewCalculator(config *config.BillingConfig) *Calculator {
 	return &Calculator{
 		config: config,
+		clock:  SystemClock{},
 	}
 }
 
@@ -71,8 +107,15 @@ func GetDaysInMonth(month, year int) int {
 	}
 }
 
-// ParseMonth parses month input in MM or YYYY-MM format
+// ParseMonth parses month input in MM or YYYY-MM format. MM months resolve
+// against the current year of the system clock.
 func ParseMonth(input string) (MonthInfo, error) {
+	return ParseMonthAt(input, SystemClock{}.Now())
+}
+
+// ParseMonthAt parses month input in MM or YYYY-MM format, resolving MM
+// months against the year of today
+func ParseMonthAt(input string, today time.Time) (MonthInfo, error) {
 	var info MonthInfo
 	info.Input = input
 
@@ -84,16 +127,16 @@ func ParseMonth(input string) (MonthInfo, error) {
 	if matches := yearMonthRegex.FindStringSubmatch(input); matches != nil {
 		year, err := strconv.Atoi(matches[1])
 		if err != nil {
-			return info, fmt.Errorf("invalid year in input: %s", input)
+			return info, inputError(ErrInvalidMonth, "months", input, "invalid year in input: %s", input)
 		}
 
 		month, err := strconv.Atoi(matches[2])
 		if err != nil {
-			return info, fmt.Errorf("invalid month in input: %s", input)
+			return info, inputError(ErrInvalidMonth, "months", input, "invalid month in input: %s", input)
 		}
 
 		if month < 1 || month > 12 {
-			return info, fmt.Errorf("invalid month: %d (must be 1-12)", month)
+			return info, inputError(ErrInvalidMonth, "months", input, "invalid month: %d (must be 1-12)", month)
 		}
 
 		info.Year = year
@@ -102,58 +145,98 @@ func ParseMonth(input string) (MonthInfo, error) {
 	} else if matches := monthRegex.FindStringSubmatch(input); matches != nil {
 		month, err := strconv.Atoi(matches[1])
 		if err != nil {
-			return info, fmt.Errorf("invalid month in input: %s", input)
+			return info, inputError(ErrInvalidMonth, "months", input, "invalid month in input: %s", input)
 		}
 
 		if month < 1 || month > 12 {
-			return info, fmt.Errorf("invalid month: %d (must be 1-12)", month)
+			return info, inputError(ErrInvalidMonth, "months", input, "invalid month: %d (must be 1-12)", month)
 		}
 
-		info.Year = time.Now().Year()
+		info.Year = today.Year()
 		info.Month = month
 		info.Days = GetDaysInMonth(month, info.Year)
 	} else {
-		return info, fmt.Errorf("invalid month format: %s (use MM or YYYY-MM)", input)
+		return info, inputError(ErrInvalidMonth, "months", input, "invalid month format: %s (use MM or YYYY-MM)", input)
 	}
 
 	return info, nil
 }
 
-// ValidateInput validates the time input
+// ValidateInput validates the time input. It reports every problem found,
+// as a *ValidationError, instead of stopping at the first one.
 func (c *Calculator) ValidateInput(input TimeInput) error {
+	var problems []error
+
 	// Validate hours
-	for _, h := range input.Hours {
+	for i, h := range input.Hours {
 		if h < 0 {
-			return fmt.Errorf("hours cannot be negative: %d", h)
+			problems = append(problems, at(inputError(ErrNegativeQuantity, "hours", fmt.Sprint(h), "hours cannot be negative: %g", h), i))
 		}
 	}
 
 	// Validate days
-	for _, d := range input.Days {
+	for i, d := range input.Days {
 		if d < 0 {
-			return fmt.Errorf("days cannot be negative: %d", d)
+			problems = append(problems, at(inputError(ErrNegativeQuantity, "days", fmt.Sprint(d), "days cannot be negative: %d", d), i))
 		}
 	}
 
 	// Validate weeks
-	for _, w := range input.Weeks {
+	for i, w := range input.Weeks {
 		if w < 0 {
-			return fmt.Errorf("weeks cannot be negative: %d", w)
+			problems = append(problems, at(inputError(ErrNegativeQuantity, "weeks", fmt.Sprint(w), "weeks cannot be negative: %d", w), i))
 		}
 	}
 
 	// Validate months
-	for _, monthStr := range input.Months {
-		if _, err := ParseMonth(monthStr); err != nil {
-			return fmt.Errorf("invalid month '%s': %v", monthStr, err)
+	for i, monthStr := range input.Months {
+		if _, err := ParsePeriod(monthStr, c.now()); err != nil {
+			problems = append(problems, &InputError{
+				Kind:  ErrInvalidMonth,
+				Field: "months",
+				Value: monthStr,
+				Index: i + 1,
+				Err:   fmt.Errorf("invalid month '%s': %w", monthStr, err),
+			})
+		}
+	}
+
+	// Validate date ranges
+	for i, r := range input.Ranges {
+		if err := validateRange(r); err != nil {
+			problems = append(problems, at(err, i))
+		}
+	}
+
+	// Validate line items
+	for i, item := range input.Items {
+		if err := validateItem(item); err != nil {
+			problems = append(problems, fmt.Errorf("invalid line item '%s': %w", item.Description, at(err, i)))
+		}
+	}
+
+	// Validate expenses
+	for i, expense := range input.Expenses {
+		if err := validateExpense(expense); err != nil {
+			problems = append(problems, fmt.Errorf("invalid expense '%s': %w", expense.Description, at(err, i)))
 		}
 	}
 
+	if len(problems) > 0 {
+		return &ValidationError{Errors: problems}
+	}
 	return nil
 }
 
 // Calculate performs the main calculation
 func (c *Calculator) Calculate(input TimeInput, currency string) (*CalculationResult, error) {
+	return c.calculate(input, currency, true)
+}
+
+// calculate calculates one input. The total minimum, volume tiers and
+// discount apply to whole invoices only, so parts of a combined invoice
+// leave them out.
+func (c *Calculator) calculate(input TimeInput, currency string, invoice bool) (*CalculationResult, error) {
 	if err := c.ValidateInput(input); err != nil {
 		return nil, err
 	}
@@ -161,16 +244,33 @@ func (c *Calculator) Calculate(input TimeInput, currency string) (*CalculationRe
 	result := &CalculationResult{
 		Currency: currency,
 	}
-
-	// Parse months
+	c.trace("calculation started", "currency", currency,
+		"hours", len(input.Hours), "days", len(input.Days), "weeks", len(input.Weeks),
+		"months", len(input.Months), "ranges", len(input.Ranges),
+		"items", len(input.Items), "expenses", len(input.Expenses))
+	c.traceRates()
+
+	// Parse months and periods, expanding them into months and date ranges
+	ranges := input.Ranges
 	for _, monthStr := range input.Months {
-		monthInfo, err := ParseMonth(monthStr)
+		period, err := ParsePeriod(monthStr, c.now())
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse month '%s': %v", monthStr, err)
+			return nil, fmt.Errorf("failed to parse month '%s': %w", monthStr, err)
 		}
-		result.MonthDetails = append(result.MonthDetails, monthInfo)
+		c.trace("period parsed", "input", monthStr, "months", len(period.Months), "ranges", len(period.Ranges))
+		result.MonthDetails = append(result.MonthDetails, period.Months...)
+		ranges = append(ranges, period.Ranges...)
+	}
+
+	// Split date ranges into per-month segments
+	for _, r := range ranges {
+		result.MonthDetails = append(result.MonthDetails, c.splitRange(r)...)
 	}
 
+	// Recurring fees are charged per billed month, not per rate period
+	billedMonths := len(result.MonthDetails)
+	result.MonthDetails = c.splitRateChanges(result.MonthDetails)
+
 	// Sum all values
 	for _, h := range input.Hours {
 		result.TotalHours += h
@@ -184,38 +284,254 @@ func (c *Calculator) Calculate(input TimeInput, currency string) (*CalculationRe
 
 	// Calculate total hours from all sources
 	totalHours := result.TotalHours
-	totalHours += result.TotalDays * c.config.HoursPerDay
-	totalHours += result.TotalWeeks * c.config.WeeklyHours
+	totalHours += float64(result.TotalDays * c.config.HoursPerDay)
+	totalHours += float64(result.TotalWeeks * c.config.WeeklyHours)
+	c.trace("hours given", "hours", result.TotalHours)
+	if result.TotalDays > 0 {
+		c.trace("days to hours", "days", result.TotalDays, "hours_per_day", c.config.HoursPerDay,
+			"hours", result.TotalDays*c.config.HoursPerDay)
+	}
+	if result.TotalWeeks > 0 {
+		c.trace("weeks to hours", "weeks", result.TotalWeeks, "weekly_hours", c.config.WeeklyHours,
+			"hours", result.TotalWeeks*c.config.WeeklyHours)
+	}
 
-	// Add hours from months
-	for _, monthInfo := range result.MonthDetails {
-		totalHours += monthInfo.Days * c.config.HoursPerDay
+	// Resolve the weeks-per-month strategy and the reference hourly rate
+	reference := c.referenceRates(result.MonthDetails)
+	result.HourlyRate = reference.Hourly
+	result.WeeksStrategy = c.config.Strategy()
+	result.WeeksPerMonth = reference.WeeksPerMonth
+	c.trace("reference rate", "weeks_strategy", result.WeeksStrategy,
+		"weeks_per_month", reference.WeeksPerMonth, "monthly_hours", reference.MonthlyHours,
+		"hourly", reference.Hourly)
+
+	// Add hours from months, each billed at its own month's rate
+	monthCorrection := 0.0
+	for i := range result.MonthDetails {
+		monthInfo := &result.MonthDetails[i]
+		c.traceMonth(*monthInfo)
+		monthInfo.Hours = float64(monthInfo.Days * c.config.HoursPerDay)
+		monthInfo.HourlyRate = c.ratesFor(*monthInfo).Hourly
+		monthInfo.Amount = monthInfo.Hours * monthInfo.HourlyRate
+		monthCorrection += monthInfo.Hours * (monthInfo.HourlyRate - result.HourlyRate)
+		totalHours += monthInfo.Hours
+		c.trace("month amount", "input", monthInfo.Input, "days", monthInfo.Days,
+			"hours_per_day", c.config.HoursPerDay, "hours", monthInfo.Hours,
+			"hourly", monthInfo.HourlyRate, "amount", monthInfo.Amount)
 	}
 
 	result.TotalTime = totalHours
-	result.TotalAmount = float64(totalHours) * c.config.HourlyRate
+	result.BilledHours = totalHours
+
+	// Round increments and apply minimums to the billed hours
+	if c.config.Pricing != nil {
+		c.adjustHours(input, result)
+		if invoice {
+			c.applyTotalMinimum(result)
+		}
+	}
+	result.LaborAmount = result.BilledHours*result.HourlyRate + monthCorrection
+	c.trace("labor amount", "billed_hours", result.BilledHours, "hourly", result.HourlyRate,
+		"month_correction", monthCorrection, "amount", result.LaborAmount)
+
+	// Prepaid hours are drawn first; only the overage is billed
+	if c.config.Retainer != nil {
+		result.Retainer = c.drawRetainer(result)
+		result.LaborAmount = result.Retainer.OverageAmount
+		c.trace("retainer", "opening_hours", result.Retainer.OpeningHours,
+			"drawn_hours", result.Retainer.DrawnHours, "expired_hours", result.Retainer.ExpiredHours,
+			"overage_hours", result.Retainer.OverageHours, "overage_rate", result.Retainer.OverageRate,
+			"amount", result.LaborAmount)
+	}
+
+	// Discounts apply to the billable labor
+	if c.config.Pricing != nil && invoice {
+		c.applyDiscounts(result)
+	}
+
+	// Price fixed fees, milestones and recurring fees
+	for _, item := range input.Items {
+		line := buildLineItem(item, billedMonths)
+		result.LineItems = append(result.LineItems, line)
+		result.ItemsAmount += line.Amount
+		c.trace("line item", "type", line.Type, "description", line.Description,
+			"quantity", line.Quantity, "unit_price", line.UnitPrice, "amount", line.Amount)
+	}
+
+	// Convert reimbursable expenses into the invoice currency
+	for _, expense := range input.Expenses {
+		line, err := c.buildExpenseLine(expense, currency)
+		if err != nil {
+			return nil, err
+		}
+		result.Expenses = append(result.Expenses, line)
+		result.ExpenseTotal += line.Total
+		c.trace("expense conversion", "description", line.Description,
+			"amount", line.OriginalAmount, "from", line.OriginalCurrency, "to", currency,
+			"exchange_rate", line.ExchangeRate, "converted", line.Amount,
+			"markup", line.Markup, "markup_amount", line.MarkupAmount, "total", line.Total)
+	}
+
+	// Labor and fees are taxed separately from expenses
+	result.LaborTax = (result.LaborAmount + result.ItemsAmount) * c.config.TaxRate / 100
+	result.ExpenseTax = result.ExpenseTotal * c.config.ExpenseTaxRate / 100
+	c.trace("labor tax", "base", result.LaborAmount+result.ItemsAmount, "rate", c.config.TaxRate, "tax", result.LaborTax)
+	c.trace("expense tax", "base", result.ExpenseTotal, "rate", c.config.ExpenseTaxRate, "tax", result.ExpenseTax)
+
+	result.TotalAmount = result.LaborAmount + result.ItemsAmount + result.ExpenseTotal +
+		result.LaborTax + result.ExpenseTax
+	c.trace("total", "labor", result.LaborAmount, "items", result.ItemsAmount,
+		"expenses", result.ExpenseTotal, "labor_tax", result.LaborTax, "expense_tax", result.ExpenseTax,
+		"amount", result.TotalAmount, "rounded", roundCents(result.TotalAmount))
 
 	return result, nil
 }
 
-// FormatResult formats the calculation result for display
+// formatHours renders an hour count without trailing zeros
+func formatHours(hours float64) string {
+	return strconv.FormatFloat(math.Round(hours*100)/100, 'f', -1, 64)
+}
+
+// FormatResult formats the calculation result for display. Results of
+// several people or projects show the breakdown of each one with its
+// subtotal.
 func (c *Calculator) FormatResult(result *CalculationResult) string {
 	var output strings.Builder
 
 	output.WriteString("=== CÁLCULO DE FACTURACIÓN ===\n\n")
+
+	split := len(result.Projects) > 0 || len(result.People) > 0
+	if split {
+		c.writeParts(&output, result)
+		c.writeRetainer(&output, result)
+	} else {
+		c.writeBreakdown(&output, result)
+		c.writeRetainer(&output, result)
+		c.writeCharges(&output, result)
+	}
+
+	output.WriteString("\nRESUMEN:\n")
+	output.WriteString(fmt.Sprintf("  Total de horas: %s\n", formatHours(result.TotalTime)))
+	if result.BilledHours > 0 && result.BilledHours != result.TotalTime {
+		output.WriteString(fmt.Sprintf("  Horas facturadas: %s\n", formatHours(result.BilledHours)))
+	}
+	hourlyRate, strategy, weeks := result.HourlyRate, result.WeeksStrategy, result.WeeksPerMonth
+	if hourlyRate == 0 {
+		hourlyRate, strategy, weeks = c.config.HourlyRate, c.config.Strategy(), c.config.MonthlyHours/float64(c.config.WeeklyHours)
+	}
+	if !split {
+		output.WriteString(fmt.Sprintf("  Tarifa por hora: %s %.2f\n", result.Currency, hourlyRate))
+	}
+	output.WriteString(fmt.Sprintf("  Semanas por mes: %s\n", weeksLabel(strategy, weeks)))
+	if split {
+		for _, person := range result.People {
+			output.WriteString(fmt.Sprintf("  Subtotal %s: %s %.2f\n",
+				personLabel(person.Person), result.Currency, person.Subtotal))
+		}
+		for _, project := range result.Projects {
+			output.WriteString(fmt.Sprintf("  Subtotal %s: %s %.2f\n",
+				projectLabel(project.Project), result.Currency, project.Subtotal))
+		}
+	} else {
+		if len(result.LineItems) > 0 || len(result.Expenses) > 0 || len(result.Adjustments) > 0 {
+			output.WriteString(fmt.Sprintf("  Subtotal horas: %s %.2f\n", result.Currency, result.LaborAmount))
+		}
+		if len(result.LineItems) > 0 {
+			output.WriteString(fmt.Sprintf("  Subtotal otros conceptos: %s %.2f\n", result.Currency, result.ItemsAmount))
+		}
+		if len(result.Expenses) > 0 {
+			output.WriteString(fmt.Sprintf("  Subtotal gastos: %s %.2f\n", result.Currency, result.ExpenseTotal))
+		}
+	}
+	if result.LaborTax > 0 {
+		output.WriteString(fmt.Sprintf("  Impuestos (%g%%): %s %.2f\n", c.config.TaxRate, result.Currency, result.LaborTax))
+	}
+	if result.ExpenseTax > 0 {
+		output.WriteString(fmt.Sprintf("  Impuestos sobre gastos (%g%%): %s %.2f\n",
+			c.config.ExpenseTaxRate, result.Currency, result.ExpenseTax))
+	}
+	output.WriteString(fmt.Sprintf("  TOTAL A FACTURAR: %s %.2f\n", result.Currency, result.TotalAmount))
+
+	return output.String()
+}
+
+// writeParts writes the breakdown and subtotal of every pair of project and
+// person, each with the rate and schedule it was billed with
+func (c *Calculator) writeParts(output *strings.Builder, result *CalculationResult) {
+	byPerson := len(result.People) > 0
+	for i, part := range result.Parts {
+		if i > 0 {
+			output.WriteString("\n")
+		}
+		calc := c.partCalculator(part, result.Currency)
+		heading := partHeading(part, c.config.Team[part.Person].Role, byPerson)
+		if part.MixedRates {
+			output.WriteString(fmt.Sprintf("--- %s (tarifas mixtas) ---\n", heading))
+		} else {
+			output.WriteString(fmt.Sprintf("--- %s (%s %.2f por hora) ---\n", heading, result.Currency, part.HourlyRate))
+		}
+		sub := resultPart(result, part)
+		calc.writeBreakdown(output, sub)
+		calc.writeCharges(output, sub)
+		output.WriteString(fmt.Sprintf("\n  Subtotal %s: %s %.2f\n", partLabel(part, byPerson), result.Currency, part.Subtotal))
+	}
+
+	first := true
+	for _, adjustment := range result.Adjustments {
+		if !adjustment.Invoice {
+			continue
+		}
+		if first {
+			output.WriteString("\nAjustes de la factura:\n")
+			first = false
+		}
+		output.WriteString(formatAdjustment(adjustment, result.Currency))
+	}
+}
+
+// writeBreakdown writes the worked time and pricing adjustments of a result
+func (c *Calculator) writeBreakdown(output *strings.Builder, result *CalculationResult) {
 	output.WriteString("Desglose de tiempo trabajado:\n")
 
+	// Whole months and date range segments are shown separately
+	perMonth := c.config.MonthDependent() || ratesVary(result.MonthDetails, result.HourlyRate)
+	var months, segments []MonthInfo
+	for _, monthInfo := range result.MonthDetails {
+		if monthInfo.From != "" {
+			segments = append(segments, monthInfo)
+		} else {
+			months = append(months, monthInfo)
+		}
+	}
+
 	// Show month details
-	if len(result.MonthDetails) > 0 {
+	if len(months) > 0 {
 		var monthParts []string
 		totalMonthDays := 0
-		for _, monthInfo := range result.MonthDetails {
-			monthParts = append(monthParts, fmt.Sprintf("%s (%d días)", monthInfo.Input, monthInfo.Days))
+		for _, monthInfo := range months {
+			monthParts = append(monthParts, fmt.Sprintf("%s (%d días)", monthInfo.Label(), monthInfo.Days))
 			totalMonthDays += monthInfo.Days
 		}
 		monthHours := totalMonthDays * c.config.HoursPerDay
 		output.WriteString(fmt.Sprintf("  Meses: %s = %d días × %d horas = %d horas\n",
 			strings.Join(monthParts, ", "), totalMonthDays, c.config.HoursPerDay, monthHours))
+
+		// Month-dependent strategies and rate changes bill every month at its own rate
+		if perMonth {
+			for _, monthInfo := range months {
+				output.WriteString(fmt.Sprintf("    %s: %s horas × %s %.2f = %s %.2f\n",
+					monthInfo.Label(), formatHours(monthInfo.Hours), result.Currency, monthInfo.HourlyRate,
+					result.Currency, monthInfo.Amount))
+			}
+		}
+	}
+
+	// Show date range segments
+	if len(segments) > 0 {
+		output.WriteString("  Rango de fechas:\n")
+		for _, monthInfo := range segments {
+			output.WriteString(c.formatSegment(monthInfo, result.Currency, perMonth))
+		}
 	}
 
 	// Show weeks
@@ -234,33 +550,109 @@ func (c *Calculator) FormatResult(result *CalculationResult) string {
 
 	// Show additional hours
 	if result.TotalHours > 0 {
-		output.WriteString(fmt.Sprintf("  Horas adicionales: %d horas\n", result.TotalHours))
+		output.WriteString(fmt.Sprintf("  Horas adicionales: %s horas\n", formatHours(result.TotalHours)))
 	}
 
-	output.WriteString("\nRESUMEN:\n")
-	output.WriteString(fmt.Sprintf("  Total de horas: %d\n", result.TotalTime))
-	output.WriteString(fmt.Sprintf("  Tarifa por hora: %s %.2f\n", result.Currency, c.config.HourlyRate))
-	output.WriteString(fmt.Sprintf("  TOTAL A FACTURAR: %s %.2f\n", result.Currency, result.TotalAmount))
+	// Show pricing adjustments
+	if len(result.Adjustments) > 0 {
+		output.WriteString("\nAjustes:\n")
+		for _, adjustment := range result.Adjustments {
+			output.WriteString(formatAdjustment(adjustment, result.Currency))
+		}
+	}
+}
 
-	return output.String()
+// writeRetainer writes the retainer balance of a result, if any
+func (c *Calculator) writeRetainer(output *strings.Builder, result *CalculationResult) {
+	// Show retainer balance
+	if r := result.Retainer; r != nil {
+		output.WriteString("\nBanco de horas:\n")
+		output.WriteString(fmt.Sprintf("  Saldo inicial: %s horas\n", formatHours(r.OpeningHours)))
+		output.WriteString(fmt.Sprintf("  Horas consumidas: %s horas\n", formatHours(r.DrawnHours)))
+		if r.ExpiredHours > 0 {
+			output.WriteString(fmt.Sprintf("  Horas vencidas: %s horas\n", formatHours(r.ExpiredHours)))
+		}
+		output.WriteString(fmt.Sprintf("  Saldo restante: %s horas\n", formatHours(r.RemainingHours)))
+		output.WriteString(fmt.Sprintf("  Horas excedentes: %s × %s %.2f = %s %.2f\n",
+			formatHours(r.OverageHours), result.Currency, r.OverageRate, result.Currency, r.OverageAmount))
+	}
+}
+
+// writeCharges writes the non-hourly charges and expenses of a result
+func (c *Calculator) writeCharges(output *strings.Builder, result *CalculationResult) {
+	// Show non-hourly charges
+	if len(result.LineItems) > 0 {
+		output.WriteString("\nOtros conceptos:\n")
+		for _, line := range result.LineItems {
+			output.WriteString(formatLineItem(line, result.Currency))
+		}
+	}
+
+	// Show reimbursable expenses
+	if len(result.Expenses) > 0 {
+		output.WriteString("\nGastos reembolsables:\n")
+		for _, line := range result.Expenses {
+			output.WriteString(formatExpenseLine(line, result.Currency))
+		}
+	}
+}
+
+// anchorLabels names each rate anchor for display
+var anchorLabels = map[string]string{
+	config.AnchorHourly:  "por hora",
+	config.AnchorDaily:   "por día",
+	config.AnchorWeekly:  "por semana",
+	config.AnchorMonthly: "mensual",
+	config.AnchorAnnual:  "anual",
 }
 
 // FormatRates formats the rates table for display
 func (c *Calculator) FormatRates(currency string) string {
 	var output strings.Builder
 
+	current, adjustment := c.config.AsOf(c.now())
+	anchor := current.Anchor()
+	reference := c.referenceRates(nil)
+	rates := []struct {
+		anchor string
+		label  string
+		amount float64
+	}{
+		{config.AnchorHourly, "Por hora", reference.Hourly},
+		{config.AnchorDaily, "Por día", reference.Daily},
+		{config.AnchorWeekly, "Por semana", reference.Weekly},
+		{config.AnchorMonthly, "Por mes", reference.Monthly},
+		{config.AnchorAnnual, "Por año", reference.Annual},
+	}
+
 	output.WriteString("=== TABLA DE TARIFAS ===\n\n")
 	output.WriteString("Configuración base:\n")
-	output.WriteString(fmt.Sprintf("  Salario mensual: %s %.2f\n", currency, c.config.MonthlySalary))
+	output.WriteString(fmt.Sprintf("  Tarifa base: %s\n", anchorLabels[anchor]))
+	if change := c.config.RateChanges(time.Time{}, c.now()); len(change) > 0 {
+		output.WriteString(fmt.Sprintf("  Vigente desde: %s\n", change[len(change)-1].EffectiveFrom))
+	}
+	output.WriteString(fmt.Sprintf("  Salario mensual: %s %.2f\n", currency, reference.Monthly))
 	output.WriteString(fmt.Sprintf("  Horas semanales: %d\n", c.config.WeeklyHours))
 	output.WriteString(fmt.Sprintf("  Días laborales: %d\n", c.config.WorkDays))
 	output.WriteString(fmt.Sprintf("  Horas por día: %d\n", c.config.HoursPerDay))
+	output.WriteString(fmt.Sprintf("  Semanas por mes: %s\n", weeksLabel(c.config.Strategy(), reference.WeeksPerMonth)))
+	output.WriteString(fmt.Sprintf("  Horas mensuales: %s\n", formatHours(reference.MonthlyHours)))
+	if c.config.MonthDependent() || adjustment != nil {
+		output.WriteString(fmt.Sprintf("  Mes de referencia: %s\n", c.now().Format("2006-01")))
+	}
 	output.WriteString(fmt.Sprintf("  Moneda: %s\n", currency))
+	if adjustment != nil {
+		output.WriteString("\nIndexación:\n")
+		output.WriteString(formatIndexAdjustment(c.config.Indexation, adjustment))
+	}
 	output.WriteString("\nTarifas calculadas:\n")
-	output.WriteString(fmt.Sprintf("  Por hora: %s %.2f\n", currency, c.config.HourlyRate))
-	output.WriteString(fmt.Sprintf("  Por día: %s %.2f\n", currency, c.config.DailyRate))
-	output.WriteString(fmt.Sprintf("  Por semana: %s %.2f\n", currency, c.config.WeeklyRate))
-	output.WriteString(fmt.Sprintf("  Por mes: %s %.2f\n", currency, c.config.MonthlySalary))
+	for _, rate := range rates {
+		marker := ""
+		if rate.anchor == anchor {
+			marker = " (base)"
+		}
+		output.WriteString(fmt.Sprintf("  %s: %s %.2f%s\n", rate.label, currency, rate.amount, marker))
+	}
 	output.WriteString("\n")
 
 	return output.String()
@@ -268,24 +660,46 @@ func (c *Calculator) FormatRates(currency string) string {
 
 // CalculateQuickRates calculates rates for common time periods
 func (c *Calculator) CalculateQuickRates(currency string) map[string]float64 {
+	reference := c.referenceRates(nil)
 	return map[string]float64{
-		"hourly":  c.config.HourlyRate,
-		"daily":   c.config.DailyRate,
-		"weekly":  c.config.WeeklyRate,
-		"monthly": c.config.MonthlySalary,
+		"hourly":  reference.Hourly,
+		"daily":   reference.Daily,
+		"weekly":  reference.Weekly,
+		"monthly": reference.Monthly,
+		"annual":  reference.Annual,
+	}
+}
+
+// MonthSummary returns the hours, rate and amount billed for a whole month
+func (c *Calculator) MonthSummary(monthInput string) (MonthInfo, error) {
+	monthInfo, err := ParseMonthAt(monthInput, c.now())
+	if err != nil {
+		return monthInfo, err
+	}
+
+	// A month straddling a rate change is billed at the average of its rates
+	monthInfo.Hours = float64(monthInfo.Days * c.config.HoursPerDay)
+	for _, part := range c.splitRateChanges([]MonthInfo{monthInfo}) {
+		monthInfo.Amount += float64(part.Days*c.config.HoursPerDay) * c.ratesFor(part).Hourly
 	}
+	if monthInfo.Hours > 0 {
+		monthInfo.HourlyRate = monthInfo.Amount / monthInfo.Hours
+	}
+	return monthInfo, nil
 }
 
 // GetMonthSummary returns a summary of hours and amount for a specific month
 func (c *Calculator) GetMonthSummary(monthInput string, currency string) (string, error) {
-	monthInfo, err := ParseMonth(monthInput)
+	monthInfo, err := c.MonthSummary(monthInput)
 	if err != nil {
 		return "", err
 	}
+	return c.FormatMonthSummary(monthInfo, currency), nil
+}
 
-	hours := monthInfo.Days * c.config.HoursPerDay
-	amount := float64(hours) * c.config.HourlyRate
-
-	return fmt.Sprintf("Mes %s: %d días × %d horas = %d horas → %s %.2f",
-		monthInput, monthInfo.Days, c.config.HoursPerDay, hours, currency, amount), nil
+// FormatMonthSummary formats the hours and amount of a month returned by
+// MonthSummary
+func (c *Calculator) FormatMonthSummary(monthInfo MonthInfo, currency string) string {
+	return fmt.Sprintf("Mes %s: %d días × %d horas = %s horas → %s %.2f",
+		monthInfo.Input, monthInfo.Days, c.config.HoursPerDay, formatHours(monthInfo.Hours), currency, monthInfo.Amount)
 }
@@ -5,7 +5,7 @@ import (
 	"testing"
 	"time"
 
-	"billctl/internal/config"
+	"github.com/develpudu/billctl/internal/config"
 )
 
 func TestIsLeapYear(t *testing.T) {
@@ -65,7 +65,8 @@ func TestGetDaysInMonth(t *testing.T) {
 }
 
 func TestParseMonth(t *testing.T) {
-	currentYear := time.Now().Year()
+	clock := FixedClock{Time: time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)}
+	currentYear := clock.Now().Year()
 
 	tests := []struct {
 		input       string
@@ -82,6 +83,16 @@ func TestParseMonth(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			input: "02",
+			expected: MonthInfo{
+				Input: "02",
+				Year:  currentYear,
+				Month: 2,
+				Days:  28, // 2025 is not a leap year
+			},
+			expectError: false,
+		},
 		{
 			input: "2024-02",
 			expected: MonthInfo{
@@ -122,7 +133,7 @@ func TestParseMonth(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.input, func(t *testing.T) {
-			result, err := ParseMonth(test.input)
+			result, err := ParseMonthAt(test.input, clock.Now())
 
 			if test.expectError {
 				if err == nil {
@@ -164,7 +175,7 @@ func TestCalculatorValidateInput(t *testing.T) {
 		{
 			name: "valid input",
 			input: TimeInput{
-				Hours:  []int{8, 4},
+				Hours:  []float64{8, 4},
 				Days:   []int{5, 10},
 				Weeks:  []int{2},
 				Months: []string{"01", "2024-02"},
@@ -174,7 +185,7 @@ func TestCalculatorValidateInput(t *testing.T) {
 		{
 			name: "negative hours",
 			input: TimeInput{
-				Hours: []int{-5},
+				Hours: []float64{-5},
 			},
 			expectError: true,
 		},
@@ -230,14 +241,14 @@ func TestCalculatorCalculate(t *testing.T) {
 		name           string
 		input          TimeInput
 		currency       string
-		expectedHours  int
+		expectedHours  float64
 		expectedAmount float64
 		expectError    bool
 	}{
 		{
 			name: "hours only",
 			input: TimeInput{
-				Hours: []int{10, 5},
+				Hours: []float64{10, 5},
 			},
 			currency:       "USD",
 			expectedHours:  15,
@@ -297,7 +308,7 @@ func TestCalculatorCalculate(t *testing.T) {
 		{
 			name: "combined calculation",
 			input: TimeInput{
-				Hours:  []int{4},
+				Hours:  []float64{4},
 				Days:   []int{3},
 				Weeks:  []int{1},
 				Months: []string{"2024-01"},
@@ -335,7 +346,7 @@ func TestCalculatorCalculate(t *testing.T) {
 			}
 
 			if result.TotalTime != test.expectedHours {
-				t.Errorf("Calculate() TotalTime = %d, want %d", result.TotalTime, test.expectedHours)
+				t.Errorf("Calculate() TotalTime = %g, want %g", result.TotalTime, test.expectedHours)
 			}
 
 			if result.TotalAmount != test.expectedAmount {
@@ -349,6 +360,52 @@ func TestCalculatorCalculate(t *testing.T) {
 	}
 }
 
+func TestCalculatorCalculateWeeksStrategy(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	if err := cfg.SetWeeksStrategy(config.WeeksWorking); err != nil {
+		t.Fatalf("SetWeeksStrategy() unexpected error: %v", err)
+	}
+	calc := NewCalculator(cfg)
+
+	// September 2024 has 21 weekdays (168h), October 2024 has 23 (184h)
+	result, err := calc.Calculate(TimeInput{
+		Hours:  []float64{10},
+		Months: []string{"2024-09", "2024-10"},
+	}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	september, october := 2200.0/168, 2200.0/184
+	if result.MonthDetails[0].HourlyRate != september {
+		t.Errorf("September HourlyRate = %.4f, want %.4f", result.MonthDetails[0].HourlyRate, september)
+	}
+	if result.MonthDetails[1].HourlyRate != october {
+		t.Errorf("October HourlyRate = %.4f, want %.4f", result.MonthDetails[1].HourlyRate, october)
+	}
+	if result.HourlyRate != september {
+		t.Errorf("HourlyRate = %.4f, want the first billed month's %.4f", result.HourlyRate, september)
+	}
+
+	want := 240*september + 248*october + 10*september
+	if diff := result.TotalAmount - want; diff > 1e-9 || diff < -1e-9 {
+		t.Errorf("TotalAmount = %.4f, want %.4f", result.TotalAmount, want)
+	}
+
+	output := calc.FormatResult(result)
+	expectedSubstrings := []string{
+		"2024-09: 240 horas × U$S 13.10 = U$S 3142.86",
+		"2024-10: 248 horas × U$S 11.96 = U$S 2965.22",
+		"Semanas por mes: 4.2 (horas laborables del mes)",
+	}
+
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() output missing expected substring: %s", expected)
+		}
+	}
+}
+
 func TestCalculatorFormatResult(t *testing.T) {
 	cfg := config.NewBillingConfig()
 	calc := NewCalculator(cfg)
@@ -377,6 +434,7 @@ func TestCalculatorFormatResult(t *testing.T) {
 		"Horas adicionales: 10 horas",
 		"RESUMEN:",
 		"Total de horas: 338",
+		"Semanas por mes: 4 (fijo)",
 		"TOTAL A FACTURAR:",
 	}
 
@@ -401,6 +459,8 @@ func TestCalculatorFormatRates(t *testing.T) {
 		"Horas semanales: 40",
 		"Días laborales: 5",
 		"Horas por día: 8",
+		"Semanas por mes: 4 (fijo)",
+		"Horas mensuales: 160",
 		"Moneda: EUR",
 		"Tarifas calculadas:",
 		"Por hora: EUR",
@@ -416,6 +476,29 @@ func TestCalculatorFormatRates(t *testing.T) {
 	}
 }
 
+func TestCalculatorFormatRatesAnchor(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	if err := cfg.SetRate(config.AnchorHourly, 25); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	calc := NewCalculator(cfg)
+
+	output := calc.FormatRates("EUR")
+
+	expectedSubstrings := []string{
+		"Tarifa base: por hora",
+		"Por hora: EUR 25.00 (base)",
+		"Por mes: EUR 4000.00\n",
+		"Por año: EUR 48000.00",
+	}
+
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatRates() output missing expected substring: %s", expected)
+		}
+	}
+}
+
 func TestCalculatorGetMonthSummary(t *testing.T) {
 	cfg := config.NewBillingConfig()
 	calc := NewCalculator(cfg)
@@ -508,7 +591,7 @@ func BenchmarkCalculate(b *testing.B) {
 	cfg := config.NewBillingConfig()
 	calc := NewCalculator(cfg)
 	input := TimeInput{
-		Hours:  []int{10, 5},
+		Hours:  []float64{10, 5},
 		Days:   []int{5, 3},
 		Weeks:  []int{2},
 		Months: []string{"2024-01", "2024-02"},
@@ -0,0 +1,48 @@
+package calculator
+
+import "time"
+
+// Clock provides the current time used to resolve relative periods such as
+// MM months, last-month or the current retainer period
+type Clock interface {
+	Now() time.Time
+}
+
+// SystemClock reads the system time in a location (nil means local time)
+type SystemClock struct {
+	Location *time.Location
+}
+
+// Now returns the current time in the clock's location
+func (s SystemClock) Now() time.Time {
+	if s.Location == nil {
+		return time.Now()
+	}
+	return time.Now().In(s.Location)
+}
+
+// FixedClock always returns the same time, for reproducible runs and tests
+type FixedClock struct {
+	Time time.Time
+}
+
+// Now returns the fixed time
+func (f FixedClock) Now() time.Time {
+	return f.Time
+}
+
+// SetClock replaces the clock used to resolve the current date
+func (c *Calculator) SetClock(clock Clock) {
+	if clock == nil {
+		clock = SystemClock{}
+	}
+	c.clock = clock
+}
+
+// now returns the current time used to resolve relative periods
+func (c *Calculator) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
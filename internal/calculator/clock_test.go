@@ -0,0 +1,82 @@
+package calculator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestParseMonthAt(t *testing.T) {
+	tests := []struct {
+		input         string
+		today         time.Time
+		expectedYear  int
+		expectedMonth int
+		expectedDays  int
+	}{
+		{"02", time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC), 2024, 2, 29},
+		{"02", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 2025, 2, 28},
+		{"2023-02", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), 2023, 2, 28},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input+"@"+test.today.Format("2006-01-02"), func(t *testing.T) {
+			result, err := ParseMonthAt(test.input, test.today)
+			if err != nil {
+				t.Fatalf("ParseMonthAt(%s) unexpected error: %v", test.input, err)
+			}
+			if result.Year != test.expectedYear || result.Month != test.expectedMonth || result.Days != test.expectedDays {
+				t.Errorf("ParseMonthAt(%s) = %d-%02d (%d days), want %d-%02d (%d days)", test.input,
+					result.Year, result.Month, result.Days, test.expectedYear, test.expectedMonth, test.expectedDays)
+			}
+		})
+	}
+}
+
+func TestCalculatorClock(t *testing.T) {
+	calc := NewCalculator(config.NewBillingConfig())
+	calc.SetClock(FixedClock{Time: time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)})
+
+	result, err := calc.Calculate(TimeInput{Months: []string{"02", "last-month"}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	for i, monthInfo := range result.MonthDetails {
+		if monthInfo.Year != 2025 || monthInfo.Month != 2 || monthInfo.Days != 28 {
+			t.Errorf("MonthDetails[%d] = %d-%02d (%d days), want 2025-02 (28 days)", i, monthInfo.Year, monthInfo.Month, monthInfo.Days)
+		}
+	}
+
+	summary, err := calc.GetMonthSummary("02", "U$S")
+	if err != nil {
+		t.Fatalf("GetMonthSummary() unexpected error: %v", err)
+	}
+	if !strings.Contains(summary, "28 días") {
+		t.Errorf("GetMonthSummary() = %s, want the 28 days of February 2025", summary)
+	}
+}
+
+func TestSystemClockLocation(t *testing.T) {
+	// 23:30 in Buenos Aires is already the next day in UTC
+	buenosAires := time.FixedZone("ART", -3*60*60)
+	instant := time.Date(2026, 11, 1, 2, 30, 0, 0, time.UTC)
+
+	local := instant.In(buenosAires)
+	calc := NewCalculator(config.NewBillingConfig())
+	calc.SetClock(FixedClock{Time: local})
+
+	period, err := ParsePeriod("this-month", calc.now())
+	if err != nil {
+		t.Fatalf("ParsePeriod() unexpected error: %v", err)
+	}
+	if period.Months[0].Month != 10 {
+		t.Errorf("this-month resolved to month %d, want 10", period.Months[0].Month)
+	}
+
+	if got := (SystemClock{Location: buenosAires}).Now().Location(); got != buenosAires {
+		t.Errorf("SystemClock.Now() location = %v, want %v", got, buenosAires)
+	}
+}
@@ -0,0 +1,72 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Input error kinds, matched with errors.Is
+var (
+	ErrInvalidMonth     = errors.New("invalid month")
+	ErrNegativeQuantity = errors.New("negative quantity")
+	ErrInvalidInput     = errors.New("invalid input") // any other invalid value
+)
+
+// InputError reports an invalid input value. It matches its Kind and the
+// underlying description with errors.Is.
+type InputError struct {
+	Kind  error  // ErrInvalidMonth, ErrNegativeQuantity or ErrInvalidInput
+	Field string // input the value was given in (hours, months, items...)
+	Value string // offending value as given
+	Index int    // occurrence of the value within its field, from 1 (0 if unknown)
+	Err   error  // description of the problem
+}
+
+// Error returns the description of the problem
+func (e *InputError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the kind and the description
+func (e *InputError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// inputError builds an InputError with a formatted description
+func inputError(kind error, field, value, format string, args ...interface{}) error {
+	return &InputError{Kind: kind, Field: field, Value: value, Err: fmt.Errorf(format, args...)}
+}
+
+// at records the position of the value of an InputError within its field,
+// counting from the zero-based index i
+func at(err error, i int) error {
+	var inputErr *InputError
+	if errors.As(err, &inputErr) {
+		inputErr.Index = i + 1
+	}
+	return err
+}
+
+// ValidationError collects every problem found while validating an input.
+// errors.Is and errors.As look through all of them.
+type ValidationError struct {
+	Errors []error
+}
+
+// Error lists the problems; a single problem reads like the problem itself
+func (e *ValidationError) Error() string {
+	if len(e.Errors) == 1 {
+		return e.Errors[0].Error()
+	}
+	messages := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(e.Errors), strings.Join(messages, "; "))
+}
+
+// Unwrap returns every problem
+func (e *ValidationError) Unwrap() []error {
+	return e.Errors
+}
@@ -0,0 +1,95 @@
+package calculator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestInputErrors(t *testing.T) {
+	calc := NewCalculator(config.NewBillingConfig())
+
+	tests := []struct {
+		name          string
+		input         TimeInput
+		expectedKind  error
+		expectedField string
+		expectedValue string
+	}{
+		{"negative hours", TimeInput{Hours: []float64{-1.5}}, ErrNegativeQuantity, "hours", "-1.5"},
+		{"negative days", TimeInput{Days: []int{-2}}, ErrNegativeQuantity, "days", "-2"},
+		{"negative weeks", TimeInput{Weeks: []int{-3}}, ErrNegativeQuantity, "weeks", "-3"},
+		{"invalid month", TimeInput{Months: []string{"2024-13"}}, ErrInvalidMonth, "months", "2024-13"},
+		{"unknown month name", TimeInput{Months: []string{"smarch"}}, ErrInvalidMonth, "months", "smarch"},
+		{"negative item", TimeInput{Items: []ItemInput{{Type: ItemFixed, Description: "Setup", Amount: -5}}}, ErrNegativeQuantity, ItemFixed, "-5"},
+		{"unknown item", TimeInput{Items: []ItemInput{{Type: "bonus", Description: "x"}}}, ErrInvalidInput, "items", "bonus"},
+		{"negative expense", TimeInput{Expenses: []ExpenseInput{{Description: "AWS", Amount: -1}}}, ErrNegativeQuantity, "expense", "-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := calc.ValidateInput(tt.input)
+			if !errors.Is(err, tt.expectedKind) {
+				t.Fatalf("ValidateInput() error = %v, want %v", err, tt.expectedKind)
+			}
+			var inputErr *InputError
+			if !errors.As(err, &inputErr) {
+				t.Fatalf("ValidateInput() error = %#v, want an *InputError", err)
+			}
+			if inputErr.Field != tt.expectedField || inputErr.Value != tt.expectedValue {
+				t.Errorf("ValidateInput() field, value = %s, %s, want %s, %s",
+					inputErr.Field, inputErr.Value, tt.expectedField, tt.expectedValue)
+			}
+		})
+	}
+}
+
+func TestParseMonthErrors(t *testing.T) {
+	for _, input := range []string{"13", "2024-00", "2024/01", "enero-20"} {
+		_, err := ParseMonth(input)
+		if !errors.Is(err, ErrInvalidMonth) {
+			t.Errorf("ParseMonth(%q) error = %v, want ErrInvalidMonth", input, err)
+		}
+	}
+
+	_, err := ParseDateRange("2026-10-10", "2026-10-01", false)
+	if !errors.Is(err, ErrInvalidInput) || errors.Is(err, ErrInvalidMonth) {
+		t.Errorf("ParseDateRange() error = %v, want ErrInvalidInput only", err)
+	}
+}
+
+func TestValidateInputCollectsErrors(t *testing.T) {
+	calc := NewCalculator(config.NewBillingConfig())
+
+	err := calc.ValidateInput(TimeInput{
+		Hours:  []float64{8, -1},
+		Days:   []int{-2},
+		Months: []string{"2024-02", "13"},
+	})
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ValidateInput() error = %#v, want a *ValidationError", err)
+	}
+
+	expected := []struct {
+		field string
+		index int
+	}{{"hours", 2}, {"days", 1}, {"months", 2}}
+	if len(validationErr.Errors) != len(expected) {
+		t.Fatalf("ValidateInput() returned %d errors, want %d: %v", len(validationErr.Errors), len(expected), err)
+	}
+	for i, problem := range validationErr.Errors {
+		var inputErr *InputError
+		if !errors.As(problem, &inputErr) {
+			t.Fatalf("Errors[%d] = %#v, want an *InputError", i, problem)
+		}
+		if inputErr.Field != expected[i].field || inputErr.Index != expected[i].index {
+			t.Errorf("Errors[%d] field, index = %s, %d, want %s, %d",
+				i, inputErr.Field, inputErr.Index, expected[i].field, expected[i].index)
+		}
+	}
+	if !errors.Is(err, ErrInvalidMonth) || !errors.Is(err, ErrNegativeQuantity) {
+		t.Errorf("ValidateInput() error = %v, want both ErrInvalidMonth and ErrNegativeQuantity", err)
+	}
+}
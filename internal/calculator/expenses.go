@@ -0,0 +1,201 @@
+package calculator
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExpenseInput describes a reimbursable cost paid on behalf of a client
+type ExpenseInput struct {
+	Description string   `json:"description"`
+	Amount      float64  `json:"amount"`
+	Currency    string   `json:"currency,omitempty"` // empty means the invoice currency
+	Markup      *float64 `json:"markup,omitempty"`   // percentage; nil uses the profile default
+	Receipt     string   `json:"receipt,omitempty"`  // reference to the receipt file
+}
+
+// ExpenseLine holds a billed expense converted into the invoice currency
+type ExpenseLine struct {
+	Description      string  `json:"description"`
+	Receipt          string  `json:"receipt,omitempty"`
+	OriginalAmount   float64 `json:"original_amount"`
+	OriginalCurrency string  `json:"original_currency"`
+	ExchangeRate     float64 `json:"exchange_rate"`
+	Amount           float64 `json:"amount"`
+	Markup           float64 `json:"markup"`
+	MarkupAmount     float64 `json:"markup_amount"`
+	Total            float64 `json:"total"`
+	Project          string  `json:"project,omitempty"`
+	Person           string  `json:"person,omitempty"`
+}
+
+// ParseExpense parses an expense flag value in
+// DESCRIPTION:AMOUNT[:CURRENCY][:markup=PERCENT][:receipt=FILE] format
+func ParseExpense(input string) (ExpenseInput, error) {
+	var expense ExpenseInput
+
+	parts := strings.Split(input, ":")
+	if len(parts) < 2 {
+		return expense, inputError(ErrInvalidInput, "expense", input, "invalid expense format: %s (use DESC:AMOUNT[:CURRENCY])", input)
+	}
+
+	expense.Description = strings.TrimSpace(parts[0])
+	if expense.Description == "" {
+		return expense, inputError(ErrInvalidInput, "expense", input, "missing description in expense: %s", input)
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return expense, inputError(ErrInvalidInput, "expense", input, "invalid amount in expense: %s", input)
+	}
+	expense.Amount = amount
+
+	for _, part := range parts[2:] {
+		part = strings.TrimSpace(part)
+		key, value, hasKey := strings.Cut(part, "=")
+		switch {
+		case !hasKey && expense.Currency == "":
+			expense.Currency = part
+		case key == "markup":
+			markup, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil {
+				return expense, inputError(ErrInvalidInput, "expense", input, "invalid markup in expense: %s", input)
+			}
+			expense.Markup = &markup
+		case key == "receipt":
+			expense.Receipt = value
+		default:
+			return expense, inputError(ErrInvalidInput, "expense", input, "unknown expense field '%s' in: %s", part, input)
+		}
+	}
+
+	return expense, validateExpense(expense)
+}
+
+// ParseExpensesCSV reads expenses from CSV with a header row. The
+// description and amount columns are required; currency, markup and
+// receipt are optional.
+func ParseExpensesCSV(r io.Reader) ([]ExpenseInput, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read expenses header: %v", err)
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"description", "amount"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("expenses file is missing the %s column", required)
+		}
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var expenses []ExpenseInput
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read expenses: %v", err)
+		}
+
+		expense := ExpenseInput{
+			Description: field(record, "description"),
+			Currency:    field(record, "currency"),
+			Receipt:     field(record, "receipt"),
+		}
+		if expense.Amount, err = strconv.ParseFloat(field(record, "amount"), 64); err != nil {
+			return nil, inputError(ErrInvalidInput, "expenses", field(record, "amount"), "invalid amount on line %d: %s", line, field(record, "amount"))
+		}
+		if value := field(record, "markup"); value != "" {
+			markup, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+			if err != nil {
+				return nil, inputError(ErrInvalidInput, "expenses", value, "invalid markup on line %d: %s", line, value)
+			}
+			expense.Markup = &markup
+		}
+		if err := validateExpense(expense); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		expenses = append(expenses, expense)
+	}
+
+	return expenses, nil
+}
+
+// validateExpense checks the values of an expense
+func validateExpense(expense ExpenseInput) error {
+	if expense.Description == "" {
+		return inputError(ErrInvalidInput, "expense", "", "expense description cannot be empty")
+	}
+	if expense.Amount < 0 {
+		return inputError(ErrNegativeQuantity, "expense", fmt.Sprint(expense.Amount), "expense amount cannot be negative: %.2f", expense.Amount)
+	}
+	if expense.Markup != nil && *expense.Markup < 0 {
+		return inputError(ErrNegativeQuantity, "expense", fmt.Sprint(*expense.Markup), "expense markup cannot be negative: %.2f", *expense.Markup)
+	}
+	return nil
+}
+
+// buildExpenseLine converts an expense into the invoice currency and applies markup
+func (c *Calculator) buildExpenseLine(expense ExpenseInput, currency string) (ExpenseLine, error) {
+	line := ExpenseLine{
+		Description:      expense.Description,
+		Receipt:          expense.Receipt,
+		OriginalAmount:   expense.Amount,
+		OriginalCurrency: expense.Currency,
+		Markup:           c.config.ExpenseMarkup,
+	}
+	if line.OriginalCurrency == "" {
+		line.OriginalCurrency = currency
+	}
+	if expense.Markup != nil {
+		line.Markup = *expense.Markup
+	}
+
+	rate, err := c.config.ExchangeRate(line.OriginalCurrency, currency)
+	if err != nil {
+		return line, fmt.Errorf("expense '%s': %w", expense.Description, err)
+	}
+
+	line.ExchangeRate = rate
+	line.Amount = expense.Amount * rate
+	line.MarkupAmount = line.Amount * line.Markup / 100
+	line.Total = line.Amount + line.MarkupAmount
+	return line, nil
+}
+
+// formatExpenseLine renders an expense for the result breakdown
+func formatExpenseLine(line ExpenseLine, currency string) string {
+	var output strings.Builder
+
+	output.WriteString(fmt.Sprintf("  %s: ", line.Description))
+	if line.ExchangeRate != 1 {
+		output.WriteString(fmt.Sprintf("%s %.2f × %.4f = ", line.OriginalCurrency, line.OriginalAmount, line.ExchangeRate))
+	}
+	output.WriteString(fmt.Sprintf("%s %.2f", currency, line.Amount))
+	if line.Markup > 0 {
+		output.WriteString(fmt.Sprintf(" + %g%% = %s %.2f", line.Markup, currency, line.Total))
+	}
+	if line.Receipt != "" {
+		output.WriteString(fmt.Sprintf(" [comprobante: %s]", line.Receipt))
+	}
+	output.WriteString("\n")
+
+	return output.String()
+}
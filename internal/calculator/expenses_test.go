@@ -0,0 +1,195 @@
+package calculator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestParseExpense(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedDesc   string
+		expectedAmount float64
+		expectedCurr   string
+		expectedMarkup float64 // -1 when not set
+		expectedRecpt  string
+		expectError    bool
+	}{
+		{"AWS:120.50:USD", "AWS", 120.50, "USD", -1, "", false},
+		{"Travel:300", "Travel", 300, "", -1, "", false},
+		{"AWS:120.50:USD:markup=10:receipt=aws.pdf", "AWS", 120.50, "USD", 10, "aws.pdf", false},
+		{"Hotel:80:receipt=hotel.jpg", "Hotel", 80, "", -1, "hotel.jpg", false},
+		{"AWS", "", 0, "", -1, "", true},
+		{":10", "", 0, "", -1, "", true},
+		{"AWS:abc", "", 0, "", -1, "", true},
+		{"AWS:-10", "", 0, "", -1, "", true},
+		{"AWS:10:USD:markup=-5", "", 0, "", -1, "", true},
+		{"AWS:10:USD:EUR", "", 0, "", -1, "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseExpense(test.input)
+
+			if test.expectError {
+				if err == nil {
+					t.Errorf("ParseExpense(%s) expected error, got nil", test.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseExpense(%s) unexpected error: %v", test.input, err)
+				return
+			}
+			if result.Description != test.expectedDesc {
+				t.Errorf("ParseExpense(%s) Description = %s, want %s", test.input, result.Description, test.expectedDesc)
+			}
+			if result.Amount != test.expectedAmount {
+				t.Errorf("ParseExpense(%s) Amount = %.2f, want %.2f", test.input, result.Amount, test.expectedAmount)
+			}
+			if result.Currency != test.expectedCurr {
+				t.Errorf("ParseExpense(%s) Currency = %s, want %s", test.input, result.Currency, test.expectedCurr)
+			}
+			if test.expectedMarkup < 0 && result.Markup != nil {
+				t.Errorf("ParseExpense(%s) Markup = %.2f, want unset", test.input, *result.Markup)
+			}
+			if test.expectedMarkup >= 0 && (result.Markup == nil || *result.Markup != test.expectedMarkup) {
+				t.Errorf("ParseExpense(%s) Markup = %v, want %.2f", test.input, result.Markup, test.expectedMarkup)
+			}
+			if result.Receipt != test.expectedRecpt {
+				t.Errorf("ParseExpense(%s) Receipt = %s, want %s", test.input, result.Receipt, test.expectedRecpt)
+			}
+		})
+	}
+}
+
+func TestParseExpensesCSV(t *testing.T) {
+	data := `description,amount,currency,markup,receipt
+AWS,120.50,USD,10,receipts/aws.pdf
+"Flight, BUE-MAD",900,EUR,,
+`
+	expenses, err := ParseExpensesCSV(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("ParseExpensesCSV() unexpected error: %v", err)
+	}
+	if len(expenses) != 2 {
+		t.Fatalf("ParseExpensesCSV() returned %d expenses, want 2", len(expenses))
+	}
+	if expenses[0].Markup == nil || *expenses[0].Markup != 10 || expenses[0].Receipt != "receipts/aws.pdf" {
+		t.Errorf("ParseExpensesCSV() first expense = %+v", expenses[0])
+	}
+	if expenses[1].Description != "Flight, BUE-MAD" || expenses[1].Markup != nil || expenses[1].Currency != "EUR" {
+		t.Errorf("ParseExpensesCSV() second expense = %+v", expenses[1])
+	}
+
+	invalid := []string{
+		"amount\n10\n",
+		"description,amount\nAWS,abc\n",
+		"description,amount\nAWS,-3\n",
+		"description,amount,markup\nAWS,3,x\n",
+	}
+	for _, data := range invalid {
+		if _, err := ParseExpensesCSV(strings.NewReader(data)); err == nil {
+			t.Errorf("ParseExpensesCSV(%q) expected error, got nil", data)
+		}
+	}
+}
+
+func TestCalculatorCalculateExpenses(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	cfg.TaxRate = 21
+	cfg.ExpenseTaxRate = 10
+	cfg.ExpenseMarkup = 5
+	cfg.ExchangeRates = map[string]float64{"EUR/USD": 1.25}
+	calc := NewCalculator(cfg)
+
+	noMarkup := 0.0
+	input := TimeInput{
+		Hours: []float64{8},
+		Expenses: []ExpenseInput{
+			{Description: "Hotel", Amount: 100, Currency: "EUR"},
+			{Description: "AWS", Amount: 200, Currency: "USD", Markup: &noMarkup},
+			{Description: "Taxi", Amount: 40},
+		},
+	}
+
+	result, err := calc.Calculate(input, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	// Hotel: 100 EUR × 1.25 = 125 + 5%; AWS: no markup; Taxi: invoice currency + 5%
+	expectedTotals := []float64{131.25, 200, 42}
+	for i, expected := range expectedTotals {
+		if result.Expenses[i].Total != expected {
+			t.Errorf("Expenses[%d].Total = %.2f, want %.2f", i, result.Expenses[i].Total, expected)
+		}
+	}
+
+	labor := 8 * cfg.HourlyRate
+	expensesTotal := 131.25 + 200 + 42
+	if result.ExpenseTotal != expensesTotal {
+		t.Errorf("ExpenseTotal = %.2f, want %.2f", result.ExpenseTotal, expensesTotal)
+	}
+	laborTax := labor * 21 / 100
+	expenseTax := expensesTotal * 10 / 100
+	if result.LaborTax != laborTax {
+		t.Errorf("LaborTax = %.2f, want %.2f", result.LaborTax, laborTax)
+	}
+	if result.ExpenseTax != expenseTax {
+		t.Errorf("ExpenseTax = %.2f, want %.2f", result.ExpenseTax, expenseTax)
+	}
+	if want := labor + expensesTotal + laborTax + expenseTax; result.TotalAmount != want {
+		t.Errorf("TotalAmount = %.2f, want %.2f", result.TotalAmount, want)
+	}
+
+	// The inverse pair is used when converting the other way
+	result, err = calc.Calculate(TimeInput{Expenses: input.Expenses[1:2]}, "EUR")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if result.Expenses[0].Amount != 160 {
+		t.Errorf("Expenses[0].Amount = %.2f, want 160.00", result.Expenses[0].Amount)
+	}
+
+	if _, err := calc.Calculate(TimeInput{Expenses: []ExpenseInput{{Description: "Taxi", Amount: 10, Currency: "ARS"}}}, "EUR"); err == nil {
+		t.Error("Calculate() expected error for missing exchange rate, got nil")
+	}
+}
+
+func TestCalculatorFormatResultExpenses(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	cfg.TaxRate = 21
+	cfg.ExchangeRates = map[string]float64{"USD/EUR": 0.9}
+	calc := NewCalculator(cfg)
+
+	markup := 10.0
+	result, err := calc.Calculate(TimeInput{
+		Hours: []float64{10},
+		Expenses: []ExpenseInput{
+			{Description: "AWS", Amount: 100, Currency: "USD", Markup: &markup, Receipt: "aws.pdf"},
+		},
+	}, "EUR")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	output := calc.FormatResult(result)
+	expectedSubstrings := []string{
+		"Gastos reembolsables:",
+		"AWS: USD 100.00 × 0.9000 = EUR 90.00 + 10% = EUR 99.00 [comprobante: aws.pdf]",
+		"Subtotal horas: EUR 137.50",
+		"Subtotal gastos: EUR 99.00",
+		"Impuestos (21%): EUR 28.88",
+		"TOTAL A FACTURAR: EUR 265.38",
+	}
+
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() output missing expected substring: %s", expected)
+		}
+	}
+}
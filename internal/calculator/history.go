@@ -0,0 +1,134 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// splitRateChanges splits every month and date range segment that straddles
+// a rate change into one part per rate. Date range parts count workdays and
+// whole-month parts count calendar days, as the unsplit period would.
+func (c *Calculator) splitRateChanges(months []MonthInfo) []MonthInfo {
+	if len(c.config.RateHistory) == 0 {
+		return months
+	}
+
+	var split []MonthInfo
+	for _, monthInfo := range months {
+		from, to := billedDates(monthInfo)
+		changes := c.config.RateChanges(from, to)
+		if len(changes) == 0 {
+			split = append(split, monthInfo)
+			continue
+		}
+
+		start := from
+		for i := 0; i <= len(changes); i++ {
+			end := to
+			if i < len(changes) {
+				end = changes[i].Since().AddDate(0, 0, -1)
+			}
+
+			part := monthInfo
+			if monthInfo.From != "" {
+				part.Input = start.Format(dateLayout) + ".." + end.Format(dateLayout)
+				part.From, part.To = start.Format(dateLayout), end.Format(dateLayout)
+				part.Days = c.config.WorkingDays(start, end)
+			} else {
+				part.Since, part.Until = start.Format(dateLayout), end.Format(dateLayout)
+				part.Days = end.Day() - start.Day() + 1
+			}
+			c.trace("rate change split", "input", monthInfo.Input,
+				"from", start.Format(dateLayout), "to", end.Format(dateLayout), "days", part.Days)
+			split = append(split, part)
+
+			start = end.AddDate(0, 0, 1)
+		}
+	}
+	return split
+}
+
+// billedDates returns the first and last date billed by a month or segment
+func billedDates(monthInfo MonthInfo) (time.Time, time.Time) {
+	if monthInfo.From != "" {
+		from, _ := time.Parse(dateLayout, monthInfo.From)
+		to, _ := time.Parse(dateLayout, monthInfo.To)
+		return from, to
+	}
+	if monthInfo.Since != "" {
+		from, _ := time.Parse(dateLayout, monthInfo.Since)
+		to, _ := time.Parse(dateLayout, monthInfo.Until)
+		return from, to
+	}
+	first := time.Date(monthInfo.Year, time.Month(monthInfo.Month), 1, 0, 0, 0, 0, time.UTC)
+	return first, first.AddDate(0, 1, -1)
+}
+
+// ratesFor returns the rates of a month or segment: those of its month, at
+// the rate in effect on its first billed date, indexed to the month
+func (c *Calculator) ratesFor(monthInfo MonthInfo) config.Rates {
+	from, _ := billedDates(monthInfo)
+	return c.ratesAt(from)
+}
+
+// ratesAt returns the rates of the month of date, at the rate in effect and
+// indexed then
+func (c *Calculator) ratesAt(date time.Time) config.Rates {
+	cfg, adjustment := c.config.AsOf(date)
+	if adjustment != nil {
+		c.trace("indexation", "date", date.Format(dateLayout), "index", adjustment.Name,
+			"base", adjustment.Base, "base_value", adjustment.BaseValue,
+			"month", adjustment.Month, "value", adjustment.Value,
+			"periods", adjustment.Periods, "factor", adjustment.Factor, "hourly", cfg.HourlyRate)
+	}
+	return cfg.RatesFor(date.Year(), int(date.Month()))
+}
+
+// IndexAdjustment returns the indexation of the rate for the current month,
+// or nil when the rate is not indexed
+func (c *Calculator) IndexAdjustment() *config.IndexAdjustment {
+	_, adjustment := c.config.AsOf(c.now())
+	return adjustment
+}
+
+// ratesVary reports whether any month is billed at another hourly rate than
+// the reference one
+func ratesVary(months []MonthInfo, hourly float64) bool {
+	for _, monthInfo := range months {
+		if math.Abs(monthInfo.HourlyRate-hourly) > 1e-9 {
+			return true
+		}
+	}
+	return false
+}
+
+// Label names a month or segment for display, with the dates of a whole
+// month billed in parts
+func (m MonthInfo) Label() string {
+	if m.Since != "" {
+		since, until := billedDates(m)
+		return fmt.Sprintf("%s del %d al %d", m.Input, since.Day(), until.Day())
+	}
+	return m.Input
+}
+
+// formatIndexAdjustment renders the index values behind an indexed rate
+func formatIndexAdjustment(indexation *config.Indexation, adjustment *config.IndexAdjustment) string {
+	if indexation.Percent != 0 {
+		every := indexation.PeriodMonths
+		if every == 0 {
+			every = 1
+		}
+		return fmt.Sprintf("  Ajuste fijo: %g%% cada %d mes(es) desde %s\n  Períodos aplicados: %d\n  Factor: %.4f\n",
+			indexation.Percent, every, adjustment.Base, adjustment.Periods, adjustment.Factor)
+	}
+	name := adjustment.Name
+	if name == "" {
+		name = "Índice"
+	}
+	return fmt.Sprintf("  %s base (%s): %g\n  %s aplicado (%s): %g\n  Factor: %.4f\n",
+		name, adjustment.Base, adjustment.BaseValue, name, adjustment.Month, adjustment.Value, adjustment.Factor)
+}
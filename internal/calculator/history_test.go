@@ -0,0 +1,154 @@
+package calculator
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func newHistoryCalculator(t *testing.T) *Calculator {
+	t.Helper()
+	cfg := config.NewBillingConfig()
+	if err := cfg.SetRate(config.AnchorHourly, 40); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.RateHistory = []config.RateChange{
+		{EffectiveFrom: "2026-07-15", RateAmount: 50},
+		{EffectiveFrom: "2026-09-01", RateAmount: 55},
+	}
+	calc := NewCalculator(cfg)
+	calc.SetClock(FixedClock{Time: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)})
+	return calc
+}
+
+func TestCalculateSplitsRateChanges(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    TimeInput
+		expected []MonthInfo // Days and HourlyRate of each line
+		amount   float64
+	}{
+		{
+			name:     "month before the change",
+			input:    TimeInput{Months: []string{"2026-06"}},
+			expected: []MonthInfo{{Days: 30, HourlyRate: 40}},
+			amount:   30 * 8 * 40,
+		},
+		{
+			name:     "month straddling a change",
+			input:    TimeInput{Months: []string{"2026-07"}},
+			expected: []MonthInfo{{Days: 14, HourlyRate: 40, Since: "2026-07-01"}, {Days: 17, HourlyRate: 50, Since: "2026-07-15"}},
+			amount:   14*8*40 + 17*8*50,
+		},
+		{
+			name: "date range straddling a change",
+			input: TimeInput{Ranges: []DateRange{{
+				From: time.Date(2026, 8, 25, 0, 0, 0, 0, time.UTC),
+				To:   time.Date(2026, 9, 4, 0, 0, 0, 0, time.UTC),
+			}}},
+			expected: []MonthInfo{{Days: 5, HourlyRate: 50}, {Days: 4, HourlyRate: 55}},
+			amount:   5*8*50 + 4*8*55,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := newHistoryCalculator(t).Calculate(test.input, "USD")
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error: %v", err)
+			}
+			if len(result.MonthDetails) != len(test.expected) {
+				t.Fatalf("Calculate() returned %d lines, want %d", len(result.MonthDetails), len(test.expected))
+			}
+			for i, expected := range test.expected {
+				line := result.MonthDetails[i]
+				if line.Days != expected.Days || line.HourlyRate != expected.HourlyRate || line.Since != expected.Since {
+					t.Errorf("MonthDetails[%d] = %d days at %.2f since %q, want %d days at %.2f since %q", i,
+						line.Days, line.HourlyRate, line.Since, expected.Days, expected.HourlyRate, expected.Since)
+				}
+			}
+			if math.Abs(result.LaborAmount-test.amount) > 0.001 {
+				t.Errorf("LaborAmount = %.2f, want %.2f", result.LaborAmount, test.amount)
+			}
+		})
+	}
+}
+
+func TestRateChangeOutput(t *testing.T) {
+	calc := newHistoryCalculator(t)
+	result, err := calc.Calculate(TimeInput{
+		Months: []string{"2026-07"},
+		Items:  []ItemInput{{Type: ItemRecurring, Description: "Hosting", Amount: 20}},
+	}, "USD")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if result.ItemsAmount != 20 {
+		t.Errorf("ItemsAmount = %.2f, want the recurring fee charged once for July", result.ItemsAmount)
+	}
+
+	output := calc.FormatResult(result)
+	for _, expected := range []string{
+		"2026-07 del 1 al 14: 112 horas × USD 40.00 = USD 4480.00",
+		"2026-07 del 15 al 31: 136 horas × USD 50.00 = USD 6800.00",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() missing %q in:\n%s", expected, output)
+		}
+	}
+
+	monthInfo, err := calc.MonthSummary("2026-07")
+	if err != nil {
+		t.Fatalf("MonthSummary() unexpected error: %v", err)
+	}
+	if monthInfo.Amount != 11280 {
+		t.Errorf("MonthSummary(2026-07) Amount = %.2f, want 11280.00", monthInfo.Amount)
+	}
+
+	rates := calc.FormatRates("USD")
+	if !strings.Contains(rates, "Vigente desde: 2026-09-01") || !strings.Contains(rates, "Por hora: USD 55.00") {
+		t.Errorf("FormatRates() does not show the current rate:\n%s", rates)
+	}
+}
+
+func TestCalculateIndexedRates(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	if err := cfg.SetRate(config.AnchorHourly, 100); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.Indexation = &config.Indexation{
+		Name:   "IPC",
+		Base:   "2026-01",
+		Values: map[string]float64{"2026-01": 1000, "2026-03": 1100, "2026-08": 1250},
+	}
+	calc := NewCalculator(cfg)
+	calc.SetClock(FixedClock{Time: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)})
+
+	result, err := calc.Calculate(TimeInput{Months: []string{"2026-01", "2026-03"}}, "ARS")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if result.MonthDetails[0].HourlyRate != 100 || math.Abs(result.MonthDetails[1].HourlyRate-110) > 1e-9 {
+		t.Errorf("Calculate() rates = %.2f, %.2f, want 100.00, 110.00",
+			result.MonthDetails[0].HourlyRate, result.MonthDetails[1].HourlyRate)
+	}
+	if expected := 248*100 + 248*110.0; math.Abs(result.LaborAmount-expected) > 0.001 {
+		t.Errorf("LaborAmount = %.2f, want %.2f", result.LaborAmount, expected)
+	}
+
+	rates := calc.FormatRates("ARS")
+	for _, expected := range []string{
+		"Mes de referencia: 2026-09",
+		"IPC base (2026-01): 1000",
+		"IPC aplicado (2026-08): 1250",
+		"Factor: 1.2500",
+		"Por hora: ARS 125.00",
+	} {
+		if !strings.Contains(rates, expected) {
+			t.Errorf("FormatRates() missing %q in:\n%s", expected, rates)
+		}
+	}
+}
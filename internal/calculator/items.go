@@ -0,0 +1,139 @@
+package calculator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Line item types
+const (
+	ItemFixed     = "fixed"
+	ItemMilestone = "milestone"
+	ItemRecurring = "recurring"
+)
+
+// ItemInput describes a non-hourly charge to include in a calculation
+type ItemInput struct {
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`            // fixed fee, project total for milestones, or monthly fee
+	Percent     float64 `json:"percent,omitempty"` // share of the project total billed by a milestone
+}
+
+// LineItem holds a billed non-hourly charge
+type LineItem struct {
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+	Project     string  `json:"project,omitempty"`
+	Person      string  `json:"person,omitempty"`
+}
+
+// ParseItem parses a line item flag value. Fixed and recurring items use
+// DESCRIPTION:AMOUNT, milestones use DESCRIPTION:PERCENT:TOTAL.
+func ParseItem(itemType, input string) (ItemInput, error) {
+	item := ItemInput{Type: itemType}
+
+	fields := 2
+	if itemType == ItemMilestone {
+		fields = 3
+	}
+
+	// Split from the right so descriptions may contain colons
+	parts := make([]string, fields)
+	rest := input
+	for i := fields - 1; i > 0; i-- {
+		idx := strings.LastIndex(rest, ":")
+		if idx < 0 {
+			return item, inputError(ErrInvalidInput, itemType, input, "invalid %s format: %s", itemType, input)
+		}
+		parts[i] = strings.TrimSpace(rest[idx+1:])
+		rest = rest[:idx]
+	}
+	parts[0] = strings.TrimSpace(rest)
+
+	if parts[0] == "" {
+		return item, inputError(ErrInvalidInput, itemType, input, "missing description in %s: %s", itemType, input)
+	}
+	item.Description = parts[0]
+
+	switch itemType {
+	case ItemFixed, ItemRecurring:
+		amount, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return item, inputError(ErrInvalidInput, itemType, input, "invalid amount in %s: %s", itemType, input)
+		}
+		item.Amount = amount
+	case ItemMilestone:
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(parts[1], "%"), 64)
+		if err != nil {
+			return item, inputError(ErrInvalidInput, itemType, input, "invalid percentage in milestone: %s", input)
+		}
+		total, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return item, inputError(ErrInvalidInput, itemType, input, "invalid project total in milestone: %s", input)
+		}
+		item.Percent = percent
+		item.Amount = total
+	default:
+		return item, inputError(ErrInvalidInput, "items", itemType, "unknown line item type: %s", itemType)
+	}
+
+	return item, validateItem(item)
+}
+
+// validateItem checks the values of a line item
+func validateItem(item ItemInput) error {
+	switch item.Type {
+	case ItemFixed, ItemRecurring:
+	case ItemMilestone:
+		if item.Percent <= 0 || item.Percent > 100 {
+			return inputError(ErrInvalidInput, item.Type, fmt.Sprint(item.Percent), "milestone percentage must be between 0 and 100, got: %.2f", item.Percent)
+		}
+	default:
+		return inputError(ErrInvalidInput, "items", item.Type, "unknown line item type: %s", item.Type)
+	}
+	if item.Amount < 0 {
+		return inputError(ErrNegativeQuantity, item.Type, fmt.Sprint(item.Amount), "%s amount cannot be negative: %.2f", item.Type, item.Amount)
+	}
+	return nil
+}
+
+// buildLineItem prices an item; recurring fees are charged once per billed month
+func buildLineItem(item ItemInput, months int) LineItem {
+	line := LineItem{
+		Type:        item.Type,
+		Description: item.Description,
+		Quantity:    1,
+		UnitPrice:   item.Amount,
+	}
+
+	switch item.Type {
+	case ItemMilestone:
+		line.Quantity = item.Percent / 100
+	case ItemRecurring:
+		if months > 0 {
+			line.Quantity = float64(months)
+		}
+	}
+
+	line.Amount = line.Quantity * line.UnitPrice
+	return line
+}
+
+// formatLineItem renders a line item for the result breakdown
+func formatLineItem(line LineItem, currency string) string {
+	switch line.Type {
+	case ItemMilestone:
+		return fmt.Sprintf("  Hito: %s (%.0f%% de %s %.2f) = %s %.2f\n",
+			line.Description, line.Quantity*100, currency, line.UnitPrice, currency, line.Amount)
+	case ItemRecurring:
+		return fmt.Sprintf("  Cargo mensual: %s: %.0f × %s %.2f = %s %.2f\n",
+			line.Description, line.Quantity, currency, line.UnitPrice, currency, line.Amount)
+	default:
+		return fmt.Sprintf("  Cargo fijo: %s = %s %.2f\n", line.Description, currency, line.Amount)
+	}
+}
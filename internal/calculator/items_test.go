@@ -0,0 +1,150 @@
+package calculator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestParseItem(t *testing.T) {
+	tests := []struct {
+		itemType    string
+		input       string
+		expected    ItemInput
+		expectError bool
+	}{
+		{
+			itemType: ItemFixed,
+			input:    "Setup:500",
+			expected: ItemInput{Type: ItemFixed, Description: "Setup", Amount: 500},
+		},
+		{
+			itemType: ItemRecurring,
+			input:    "Hosting: 49.90",
+			expected: ItemInput{Type: ItemRecurring, Description: "Hosting", Amount: 49.90},
+		},
+		{
+			itemType: ItemMilestone,
+			input:    "Phase 1: design:25%:8000",
+			expected: ItemInput{Type: ItemMilestone, Description: "Phase 1: design", Percent: 25, Amount: 8000},
+		},
+		{itemType: ItemFixed, input: "Setup", expectError: true},
+		{itemType: ItemFixed, input: ":500", expectError: true},
+		{itemType: ItemFixed, input: "Setup:abc", expectError: true},
+		{itemType: ItemFixed, input: "Setup:-5", expectError: true},
+		{itemType: ItemMilestone, input: "Design:8000", expectError: true},
+		{itemType: ItemMilestone, input: "Design:120:8000", expectError: true},
+		{itemType: "bonus", input: "Bonus:100", expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			result, err := ParseItem(test.itemType, test.input)
+
+			if test.expectError {
+				if err == nil {
+					t.Errorf("ParseItem(%s, %s) expected error, got nil", test.itemType, test.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseItem(%s, %s) unexpected error: %v", test.itemType, test.input, err)
+				return
+			}
+			if result != test.expected {
+				t.Errorf("ParseItem(%s, %s) = %+v, want %+v", test.itemType, test.input, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestCalculatorCalculateItems(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	calc := NewCalculator(cfg)
+
+	input := TimeInput{
+		Hours:  []float64{10},
+		Months: []string{"2024-01", "2024-02"},
+		Items: []ItemInput{
+			{Type: ItemFixed, Description: "Setup", Amount: 500},
+			{Type: ItemMilestone, Description: "Design", Percent: 25, Amount: 8000},
+			{Type: ItemRecurring, Description: "Hosting", Amount: 50},
+		},
+	}
+
+	result, err := calc.Calculate(input, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	expectedAmounts := []float64{500, 2000, 100}
+	if len(result.LineItems) != len(expectedAmounts) {
+		t.Fatalf("Calculate() LineItems = %d, want %d", len(result.LineItems), len(expectedAmounts))
+	}
+	for i, expected := range expectedAmounts {
+		if result.LineItems[i].Amount != expected {
+			t.Errorf("LineItems[%d].Amount = %.2f, want %.2f", i, result.LineItems[i].Amount, expected)
+		}
+	}
+
+	labor := float64(10+60*8) * cfg.HourlyRate
+	if result.LaborAmount != labor {
+		t.Errorf("LaborAmount = %.2f, want %.2f", result.LaborAmount, labor)
+	}
+	if result.ItemsAmount != 2600 {
+		t.Errorf("ItemsAmount = %.2f, want 2600.00", result.ItemsAmount)
+	}
+	if result.TotalAmount != labor+2600 {
+		t.Errorf("TotalAmount = %.2f, want %.2f", result.TotalAmount, labor+2600)
+	}
+
+	// Recurring fees without billed months are charged once
+	result, err = calc.Calculate(TimeInput{Items: input.Items[2:]}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if result.TotalAmount != 50 {
+		t.Errorf("TotalAmount = %.2f, want 50.00", result.TotalAmount)
+	}
+
+	if _, err := calc.Calculate(TimeInput{Items: []ItemInput{{Type: ItemFixed, Amount: -1}}}, "U$S"); err == nil {
+		t.Error("Calculate() expected error for negative fee, got nil")
+	}
+}
+
+func TestCalculatorFormatResultItems(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	calc := NewCalculator(cfg)
+
+	result, err := calc.Calculate(TimeInput{
+		Hours:  []float64{8},
+		Months: []string{"2024-01"},
+		Items: []ItemInput{
+			{Type: ItemFixed, Description: "Setup", Amount: 500},
+			{Type: ItemMilestone, Description: "Design", Percent: 25, Amount: 8000},
+			{Type: ItemRecurring, Description: "Hosting", Amount: 50},
+		},
+	}, "EUR")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	output := calc.FormatResult(result)
+	expectedSubstrings := []string{
+		"Otros conceptos:",
+		"Cargo fijo: Setup = EUR 500.00",
+		"Hito: Design (25% de EUR 8000.00) = EUR 2000.00",
+		"Cargo mensual: Hosting: 1 × EUR 50.00 = EUR 50.00",
+		"Subtotal horas: EUR 3520.00",
+		"Subtotal otros conceptos: EUR 2550.00",
+		"TOTAL A FACTURAR: EUR 6070.00",
+	}
+
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() output missing expected substring: %s", expected)
+		}
+	}
+}
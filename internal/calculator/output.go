@@ -0,0 +1,164 @@
+package calculator
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// Output formats
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputCSV  = "csv"
+)
+
+// ValidateOutput checks that an output format is supported
+func ValidateOutput(format string) error {
+	switch format {
+	case OutputText, OutputJSON, OutputCSV:
+		return nil
+	default:
+		return inputError(ErrInvalidInput, "output", format, "invalid output format: %s (use text, json or csv)", format)
+	}
+}
+
+// Format renders a calculation result in the given output format
+func (c *Calculator) Format(result *CalculationResult, format string) (string, error) {
+	switch format {
+	case OutputJSON:
+		return FormatJSON(result)
+	case OutputCSV:
+		return c.FormatCSV(result), nil
+	case OutputText:
+		return c.FormatResult(result), nil
+	default:
+		return "", ValidateOutput(format)
+	}
+}
+
+// FormatJSON renders any result as indented JSON
+func FormatJSON(v interface{}) (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JSON: %v", err)
+	}
+	return string(data) + "\n", nil
+}
+
+// csvColumns are the columns of a calculation result in CSV format
+var csvColumns = []string{"section", "description", "quantity", "unit_price", "amount"}
+
+// formatAmount renders a money amount for CSV output
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
+
+// FormatCSV renders the calculation breakdown as CSV, one row per billed
+// concept followed by the summary rows
+func (c *Calculator) FormatCSV(result *CalculationResult) string {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write(csvColumns)
+
+	row := func(section, description string, quantity, unitPrice, amount float64) {
+		writer.Write([]string{section, description, formatHours(quantity), formatAmount(unitPrice), formatAmount(amount)})
+	}
+	total := func(description string, amount float64) {
+		writer.Write([]string{"summary", description, "", "", formatAmount(amount)})
+	}
+
+	for _, monthInfo := range result.MonthDetails {
+		row("time", monthInfo.Label(), monthInfo.Hours, monthInfo.HourlyRate, monthInfo.Amount)
+	}
+	// Weeks, days and hours of each person or project use their own schedule
+	// and rate
+	timeRows := func(cfg *config.BillingConfig, subtotals Subtotals, hourlyRate float64, suffix string) {
+		if subtotals.TotalWeeks > 0 {
+			hours := float64(subtotals.TotalWeeks * cfg.WeeklyHours)
+			row("time", "weeks"+suffix, hours, hourlyRate, hours*hourlyRate)
+		}
+		if subtotals.TotalDays > 0 {
+			hours := float64(subtotals.TotalDays * cfg.HoursPerDay)
+			row("time", "days"+suffix, hours, hourlyRate, hours*hourlyRate)
+		}
+		if subtotals.TotalHours > 0 {
+			row("time", "hours"+suffix, subtotals.TotalHours, hourlyRate, subtotals.TotalHours*hourlyRate)
+		}
+	}
+	if len(result.Parts) > 0 {
+		byPerson := len(result.People) > 0
+		for _, part := range result.Parts {
+			calc := c.partCalculator(part, result.Currency)
+			rate := part.HourlyRate
+			if part.MixedRates {
+				rate = calc.config.HourlyRate
+			}
+			timeRows(calc.config, part.Subtotals, rate, " "+partLabel(part, byPerson))
+		}
+	} else {
+		timeRows(c.config, Subtotals{TotalWeeks: result.TotalWeeks, TotalDays: result.TotalDays, TotalHours: result.TotalHours}, result.HourlyRate, "")
+	}
+	for _, adjustment := range result.Adjustments {
+		writer.Write([]string{"adjustment", adjustment.Description, formatHours(adjustment.Hours), "", formatAmount(adjustment.Amount)})
+	}
+	if r := result.Retainer; r != nil {
+		row("retainer", "overage", r.OverageHours, r.OverageRate, r.OverageAmount)
+	}
+	for _, line := range result.LineItems {
+		row("item", line.Description, line.Quantity, line.UnitPrice, line.Amount)
+	}
+	for _, line := range result.Expenses {
+		row("expense", line.Description, 1, line.Amount, line.Total)
+	}
+
+	total("labor", result.LaborAmount)
+	total("items", result.ItemsAmount)
+	total("expenses", result.ExpenseTotal)
+	total("tax", result.LaborTax)
+	total("expense_tax", result.ExpenseTax)
+	total("total", result.TotalAmount)
+
+	writer.Flush()
+	return buf.String()
+}
+
+// FormatRatesCSV renders the rate table as CSV
+func (c *Calculator) FormatRatesCSV(currency string) string {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"unit", "amount", "currency"})
+
+	rates := c.CalculateQuickRates(currency)
+	for _, unit := range []string{"hourly", "daily", "weekly", "monthly", "annual"} {
+		writer.Write([]string{unit, formatAmount(rates[unit]), currency})
+	}
+
+	writer.Flush()
+	return buf.String()
+}
+
+// FormatMonthsCSV renders month summaries as CSV, one row per month
+func FormatMonthsCSV(months []MonthInfo, currency string) string {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	writer.Write([]string{"month", "days", "hours", "hourly_rate", "amount", "currency"})
+
+	for _, monthInfo := range months {
+		writer.Write([]string{
+			fmt.Sprintf("%04d-%02d", monthInfo.Year, monthInfo.Month),
+			strconv.Itoa(monthInfo.Days),
+			formatHours(monthInfo.Hours),
+			formatAmount(monthInfo.HourlyRate),
+			formatAmount(monthInfo.Amount),
+			currency,
+		})
+	}
+
+	writer.Flush()
+	return buf.String()
+}
@@ -0,0 +1,84 @@
+package calculator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestCalculatorFormat(t *testing.T) {
+	calc := NewCalculator(config.NewBillingConfig())
+	result, err := calc.Calculate(TimeInput{
+		Hours:  []float64{4},
+		Days:   []int{2},
+		Months: []string{"2024-02"},
+		Items:  []ItemInput{{Type: ItemFixed, Description: "Setup", Amount: 500}},
+	}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	jsonOutput, err := calc.Format(result, OutputJSON)
+	if err != nil {
+		t.Fatalf("Format(json) unexpected error: %v", err)
+	}
+	var decoded CalculationResult
+	if err := json.Unmarshal([]byte(jsonOutput), &decoded); err != nil {
+		t.Fatalf("Format(json) produced invalid JSON: %v", err)
+	}
+	if decoded.TotalAmount != result.TotalAmount || len(decoded.MonthDetails) != 1 || len(decoded.LineItems) != 1 {
+		t.Errorf("Format(json) round trip = %+v, want %+v", decoded, *result)
+	}
+	if !strings.Contains(jsonOutput, `"total_amount"`) {
+		t.Error("Format(json) should use snake_case field names")
+	}
+
+	csvOutput, err := calc.Format(result, OutputCSV)
+	if err != nil {
+		t.Fatalf("Format(csv) unexpected error: %v", err)
+	}
+	expectedLines := []string{
+		"section,description,quantity,unit_price,amount",
+		"time,2024-02,232,13.75,3190.00",
+		"time,days,16,13.75,220.00",
+		"time,hours,4,13.75,55.00",
+		"item,Setup,1,500.00,500.00",
+		"summary,labor,,,3465.00",
+		"summary,total,,,3965.00",
+	}
+	for _, expected := range expectedLines {
+		if !strings.Contains(csvOutput, expected+"\n") {
+			t.Errorf("Format(csv) output missing expected line: %s", expected)
+		}
+	}
+
+	textOutput, err := calc.Format(result, OutputText)
+	if err != nil || textOutput != calc.FormatResult(result) {
+		t.Errorf("Format(text) should match FormatResult(), got error %v", err)
+	}
+
+	if _, err := calc.Format(result, "xml"); err == nil {
+		t.Error("Format(xml) expected error, got nil")
+	}
+}
+
+func TestFormatMonthsCSV(t *testing.T) {
+	calc := NewCalculator(config.NewBillingConfig())
+	var months []MonthInfo
+	for _, input := range []string{"2024-02", "2023-02"} {
+		monthInfo, err := calc.MonthSummary(input)
+		if err != nil {
+			t.Fatalf("MonthSummary(%s) unexpected error: %v", input, err)
+		}
+		months = append(months, monthInfo)
+	}
+
+	expected := "month,days,hours,hourly_rate,amount,currency\n" +
+		"2024-02,29,232,13.75,3190.00,U$S\n" +
+		"2023-02,28,224,13.75,3080.00,U$S\n"
+	if output := FormatMonthsCSV(months, "U$S"); output != expected {
+		t.Errorf("FormatMonthsCSV() = %q, want %q", output, expected)
+	}
+}
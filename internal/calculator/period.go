@@ -0,0 +1,178 @@
+package calculator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Period is a billing period expanded into whole months and date ranges
+type Period struct {
+	Input  string
+	Months []MonthInfo
+	Ranges []DateRange
+}
+
+// monthNames maps English and Spanish month names and abbreviations to months
+var monthNames = map[string]int{
+	"jan": 1, "january": 1, "ene": 1, "enero": 1,
+	"feb": 2, "february": 2, "febrero": 2,
+	"mar": 3, "march": 3, "marzo": 3,
+	"apr": 4, "april": 4, "abr": 4, "abril": 4,
+	"may": 5, "mayo": 5,
+	"jun": 6, "june": 6, "junio": 6,
+	"jul": 7, "july": 7, "julio": 7,
+	"aug": 8, "august": 8, "ago": 8, "agosto": 8,
+	"sep": 9, "sept": 9, "september": 9, "septiembre": 9, "setiembre": 9,
+	"oct": 10, "october": 10, "octubre": 10,
+	"nov": 11, "november": 11, "noviembre": 11,
+	"dec": 12, "december": 12, "dic": 12, "diciembre": 12,
+}
+
+var (
+	quarterRegex   = regexp.MustCompile(`^(\d{4})-[Qq]([1-4])$`)
+	weekRegex      = regexp.MustCompile(`^(\d{4})-[Ww](\d{1,2})$`)
+	monthNameRegex = regexp.MustCompile(`^([a-z]+)(?:[- ](\d{4}))?$`)
+	monthRegex     = regexp.MustCompile(`^(?:\d{4}-)?\d{1,2}$`)
+)
+
+// ParsePeriod parses a billing period. On top of the MM and YYYY-MM formats
+// of ParseMonth it accepts quarters (2026-Q3), ISO weeks (2026-W41), month
+// ranges (2026-01..2026-03), date ranges (2026-09-12..2026-10-11), month
+// names in English or Spanish (feb, febrero, feb-2026) and the relative
+// periods this-month, last-month, this-quarter, last-quarter and ytd, which
+// resolve against today.
+func ParsePeriod(input string, today time.Time) (Period, error) {
+	period := Period{Input: input}
+	value := strings.ToLower(strings.TrimSpace(input))
+	year, month := today.Year(), int(today.Month())
+
+	switch value {
+	case "this-month":
+		period.Months = monthSpan(year, month, 1)
+		return period, nil
+	case "last-month":
+		period.Months = monthSpan(year, month-1, 1)
+		return period, nil
+	case "this-quarter":
+		period.Months = monthSpan(year, quarterStart(month), 3)
+		return period, nil
+	case "last-quarter":
+		period.Months = monthSpan(year, quarterStart(month)-3, 3)
+		return period, nil
+	case "ytd":
+		// Whole months up to the previous one, then the current month to date
+		period.Months = monthSpan(year, 1, month-1)
+		first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		last := time.Date(year, time.Month(month), today.Day(), 0, 0, 0, 0, time.UTC)
+		period.Ranges = []DateRange{{From: first, To: last}}
+		return period, nil
+	}
+
+	if from, to, ok := strings.Cut(value, ".."); ok {
+		return parseSpan(period, from, to)
+	}
+
+	if matches := quarterRegex.FindStringSubmatch(value); matches != nil {
+		year, _ := strconv.Atoi(matches[1])
+		quarter, _ := strconv.Atoi(matches[2])
+		period.Months = monthSpan(year, quarter*3-2, 3)
+		return period, nil
+	}
+
+	if matches := weekRegex.FindStringSubmatch(value); matches != nil {
+		year, _ := strconv.Atoi(matches[1])
+		week, _ := strconv.Atoi(matches[2])
+		monday, err := isoWeekStart(year, week)
+		if err != nil {
+			return period, err
+		}
+		period.Ranges = []DateRange{{From: monday, To: monday.AddDate(0, 0, 6)}}
+		return period, nil
+	}
+
+	if matches := monthNameRegex.FindStringSubmatch(value); matches != nil {
+		month, ok := monthNames[matches[1]]
+		if !ok {
+			return period, inputError(ErrInvalidMonth, "months", input, "unknown month name: %s", matches[1])
+		}
+		if matches[2] != "" {
+			year, _ = strconv.Atoi(matches[2])
+		}
+		info := MonthInfo{Input: input, Year: year, Month: month, Days: GetDaysInMonth(month, year)}
+		period.Months = []MonthInfo{info}
+		return period, nil
+	}
+
+	info, err := ParseMonthAt(input, today)
+	if err != nil {
+		// MM and YYYY-MM keep the month parser's own error
+		if monthRegex.MatchString(input) {
+			return period, err
+		}
+		return period, inputError(ErrInvalidMonth, "months", input, "invalid period format: %s (use MM, YYYY-MM, YYYY-Qn, YYYY-Wnn, a range or a month name)", input)
+	}
+	period.Months = []MonthInfo{info}
+	return period, nil
+}
+
+// parseSpan parses FROM..TO as a range of whole months (YYYY-MM) or of
+// dates (YYYY-MM-DD), both ends inclusive
+func parseSpan(period Period, from, to string) (Period, error) {
+	if r, err := ParseDateRange(from, to, false); err == nil {
+		period.Ranges = []DateRange{r}
+		return period, nil
+	}
+
+	start, err := time.Parse("2006-01", from)
+	if err != nil {
+		return period, inputError(ErrInvalidMonth, "months", period.Input, "invalid range start: %s (use YYYY-MM or YYYY-MM-DD)", from)
+	}
+	end, err := time.Parse("2006-01", to)
+	if err != nil {
+		return period, inputError(ErrInvalidMonth, "months", period.Input, "invalid range end: %s (use YYYY-MM or YYYY-MM-DD)", to)
+	}
+
+	count := periodIndex(end.Year(), int(end.Month())) - periodIndex(start.Year(), int(start.Month())) + 1
+	if count <= 0 {
+		return period, inputError(ErrInvalidMonth, "months", period.Input, "month range is empty: %s", period.Input)
+	}
+	period.Months = monthSpan(start.Year(), int(start.Month()), count)
+	return period, nil
+}
+
+// monthSpan returns count consecutive whole months starting at year and
+// month; months outside 1-12 roll over into the adjacent years
+func monthSpan(year, month, count int) []MonthInfo {
+	var months []MonthInfo
+	for i := 0; i < count; i++ {
+		index := periodIndex(year, month+i)
+		y, m := index/12, index%12+1
+		months = append(months, MonthInfo{
+			Input: formatPeriod(index),
+			Year:  y,
+			Month: m,
+			Days:  GetDaysInMonth(m, y),
+		})
+	}
+	return months
+}
+
+// quarterStart returns the first month of the quarter containing month
+func quarterStart(month int) int {
+	return (month-1)/3*3 + 1
+}
+
+// isoWeekStart returns the Monday of an ISO 8601 week
+func isoWeekStart(year, week int) (time.Time, error) {
+	// January 4th always falls in week 1
+	jan4 := time.Date(year, 1, 4, 0, 0, 0, 0, time.UTC)
+	monday := jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+(week-1)*7)
+
+	if y, w := monday.ISOWeek(); week < 1 || y != year || w != week {
+		return time.Time{}, inputError(ErrInvalidMonth, "months", fmt.Sprintf("%d-W%02d", year, week), "invalid week: %d (year %d has no such ISO week)", week, year)
+	}
+	return monday, nil
+}
@@ -0,0 +1,190 @@
+package calculator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestParsePeriod(t *testing.T) {
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input          string
+		expectedMonths []string // YYYY-MM of every whole month
+		expectedDays   int      // days of the whole months
+		expectedRanges []string // FROM..TO of every date range
+		expectError    bool
+	}{
+		// Formats accepted by ParseMonth
+		{input: "02", expectedMonths: []string{"2026-02"}, expectedDays: 28},
+		{input: "2024-02", expectedMonths: []string{"2024-02"}, expectedDays: 29},
+		{input: "2024-13", expectError: true},
+		{input: "13", expectError: true},
+
+		// Quarters
+		{input: "2026-Q1", expectedMonths: []string{"2026-01", "2026-02", "2026-03"}, expectedDays: 90},
+		{input: "2026-Q3", expectedMonths: []string{"2026-07", "2026-08", "2026-09"}, expectedDays: 92},
+		{input: "2024-q1", expectedMonths: []string{"2024-01", "2024-02", "2024-03"}, expectedDays: 91},
+		{input: "2026-Q5", expectError: true},
+		{input: "2026-Q0", expectError: true},
+
+		// ISO weeks
+		{input: "2026-W41", expectedRanges: []string{"2026-10-05..2026-10-11"}},
+		{input: "2026-W01", expectedRanges: []string{"2025-12-29..2026-01-04"}},
+		{input: "2026-w1", expectedRanges: []string{"2025-12-29..2026-01-04"}},
+		{input: "2026-W53", expectedRanges: []string{"2026-12-28..2027-01-03"}},
+		{input: "2025-W53", expectError: true}, // 2025 has 52 ISO weeks
+		{input: "2026-W00", expectError: true},
+		{input: "2026-W54", expectError: true},
+
+		// Month and date ranges
+		{input: "2026-01..2026-03", expectedMonths: []string{"2026-01", "2026-02", "2026-03"}, expectedDays: 90},
+		{input: "2025-11..2026-02", expectedMonths: []string{"2025-11", "2025-12", "2026-01", "2026-02"}, expectedDays: 120},
+		{input: "2026-05..2026-05", expectedMonths: []string{"2026-05"}, expectedDays: 31},
+		{input: "2026-03..2026-01", expectError: true},
+		{input: "2026-01..", expectError: true},
+		{input: "2026-09-12..2026-10-11", expectedRanges: []string{"2026-09-12..2026-10-11"}},
+		{input: "2026-10-11..2026-09-12", expectError: true},
+
+		// Month names
+		{input: "feb", expectedMonths: []string{"2026-02"}, expectedDays: 28},
+		{input: "febrero", expectedMonths: []string{"2026-02"}, expectedDays: 28},
+		{input: "February", expectedMonths: []string{"2026-02"}, expectedDays: 28},
+		{input: "ene", expectedMonths: []string{"2026-01"}, expectedDays: 31},
+		{input: "agosto", expectedMonths: []string{"2026-08"}, expectedDays: 31},
+		{input: "setiembre", expectedMonths: []string{"2026-09"}, expectedDays: 30},
+		{input: "dic", expectedMonths: []string{"2026-12"}, expectedDays: 31},
+		{input: "feb-2024", expectedMonths: []string{"2024-02"}, expectedDays: 29},
+		{input: "febrero 2024", expectedMonths: []string{"2024-02"}, expectedDays: 29},
+		{input: "fevereiro", expectError: true},
+
+		// Relative periods
+		{input: "this-month", expectedMonths: []string{"2026-10"}, expectedDays: 31},
+		{input: "last-month", expectedMonths: []string{"2026-09"}, expectedDays: 30},
+		{input: "this-quarter", expectedMonths: []string{"2026-10", "2026-11", "2026-12"}, expectedDays: 92},
+		{input: "last-quarter", expectedMonths: []string{"2026-07", "2026-08", "2026-09"}, expectedDays: 92},
+		{
+			input: "ytd",
+			expectedMonths: []string{"2026-01", "2026-02", "2026-03", "2026-04", "2026-05",
+				"2026-06", "2026-07", "2026-08", "2026-09"},
+			expectedDays:   273,
+			expectedRanges: []string{"2026-10-01..2026-10-18"},
+		},
+
+		// Invalid formats
+		{input: "", expectError: true},
+		{input: "invalid", expectError: true},
+		{input: "2026", expectError: true},
+		{input: "2026-Q3-W41", expectError: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			period, err := ParsePeriod(test.input, today)
+
+			if test.expectError {
+				if err == nil {
+					t.Errorf("ParsePeriod(%s) expected error, got nil", test.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParsePeriod(%s) unexpected error: %v", test.input, err)
+				return
+			}
+
+			var months []string
+			days := 0
+			for _, monthInfo := range period.Months {
+				months = append(months, formatPeriod(periodIndex(monthInfo.Year, monthInfo.Month)))
+				days += monthInfo.Days
+			}
+			var ranges []string
+			for _, r := range period.Ranges {
+				ranges = append(ranges, r.String())
+			}
+
+			if !reflect.DeepEqual(months, test.expectedMonths) {
+				t.Errorf("ParsePeriod(%s) months = %v, want %v", test.input, months, test.expectedMonths)
+			}
+			if days != test.expectedDays {
+				t.Errorf("ParsePeriod(%s) days = %d, want %d", test.input, days, test.expectedDays)
+			}
+			if !reflect.DeepEqual(ranges, test.expectedRanges) {
+				t.Errorf("ParsePeriod(%s) ranges = %v, want %v", test.input, ranges, test.expectedRanges)
+			}
+		})
+	}
+}
+
+func TestParsePeriodKeepsMonthErrors(t *testing.T) {
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	for _, input := range []string{"13", "2024-00"} {
+		_, err := ParsePeriod(input, today)
+		if err == nil || !strings.Contains(err.Error(), "must be 1-12") {
+			t.Errorf("ParsePeriod(%s) error = %v, want the month range error", input, err)
+		}
+	}
+	if _, err := ParsePeriod("2024-1-1-1", today); err == nil || !strings.Contains(err.Error(), "invalid period format") {
+		t.Errorf("ParsePeriod(2024-1-1-1) error = %v, want the period format error", err)
+	}
+}
+
+func TestParsePeriodRollsOverYears(t *testing.T) {
+	january := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"last-month", []string{"2025-12"}},
+		{"last-quarter", []string{"2025-10", "2025-11", "2025-12"}},
+		{"this-quarter", []string{"2026-01", "2026-02", "2026-03"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			period, err := ParsePeriod(test.input, january)
+			if err != nil {
+				t.Fatalf("ParsePeriod(%s) unexpected error: %v", test.input, err)
+			}
+
+			var months []string
+			for _, monthInfo := range period.Months {
+				months = append(months, monthInfo.Input)
+			}
+			if !reflect.DeepEqual(months, test.expected) {
+				t.Errorf("ParsePeriod(%s) months = %v, want %v", test.input, months, test.expected)
+			}
+		})
+	}
+
+	// Year to date in January only has the current month so far
+	period, _ := ParsePeriod("ytd", january)
+	if len(period.Months) != 0 || len(period.Ranges) != 1 || period.Ranges[0].String() != "2026-01-01..2026-01-15" {
+		t.Errorf("ParsePeriod(ytd) = %+v, want only 2026-01-01..2026-01-15", period)
+	}
+}
+
+func TestCalculatorCalculatePeriods(t *testing.T) {
+	calc := NewCalculator(config.NewBillingConfig())
+
+	result, err := calc.Calculate(TimeInput{Months: []string{"2026-Q3", "2026-W41"}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	if len(result.MonthDetails) != 4 {
+		t.Fatalf("MonthDetails has %d entries, want 4", len(result.MonthDetails))
+	}
+	// 92 days of the quarter plus the 5 workdays of week 41
+	if result.TotalTime != 97*8 {
+		t.Errorf("TotalTime = %g, want %d", result.TotalTime, 97*8)
+	}
+}
@@ -0,0 +1,192 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// Adjustment holds a pricing rule applied to the billed labor. Rounding and
+// minimums add hours; discounts only carry a (negative) amount.
+type Adjustment struct {
+	Description string  `json:"description"`
+	Hours       float64 `json:"hours"`
+	Amount      float64 `json:"amount"`
+	Project     string  `json:"project,omitempty"`
+	Person      string  `json:"person,omitempty"`
+	Invoice     bool    `json:"invoice,omitempty"` // applies to the whole invoice, not to one project or person
+}
+
+// roundIncrement rounds hours to a billing increment
+func roundIncrement(hours, increment float64, mode string) float64 {
+	units := hours / increment
+	if mode == config.RoundNearest {
+		units = math.Round(units)
+	} else {
+		// Tolerate float noise so exact multiples are not rounded up
+		units = math.Ceil(units - 1e-9)
+	}
+	return math.Round(units*increment*1e6) / 1e6
+}
+
+// adjustHours applies rounding increments and minimums. Every hours entry
+// counts as one worked day.
+func (c *Calculator) adjustHours(input TimeInput, result *CalculationResult) {
+	p := c.config.Pricing
+	rate := result.HourlyRate
+
+	rounding, minimum := 0.0, 0.0
+	for _, h := range input.Hours {
+		if h == 0 {
+			continue
+		}
+
+		billed := h
+		if p.Increment > 0 {
+			billed = roundIncrement(h, p.Increment, p.Rounding)
+			rounding += billed - h
+			c.trace("rounding", "hours", h, "increment", p.Increment, "mode", p.Rounding, "billed", billed)
+		}
+		if p.DailyMinimum > 0 && billed < p.DailyMinimum {
+			minimum += p.DailyMinimum - billed
+			c.trace("daily minimum", "billed", billed, "minimum", p.DailyMinimum)
+		}
+	}
+
+	if rounding != 0 {
+		mode := "hacia arriba"
+		if p.Rounding == config.RoundNearest {
+			mode = "al más cercano"
+		}
+		result.Adjustments = append(result.Adjustments, Adjustment{
+			Description: fmt.Sprintf("Redondeo a incrementos de %s horas (%s)", formatHours(p.Increment), mode),
+			Hours:       rounding,
+			Amount:      rounding * rate,
+		})
+	}
+	if minimum > 0 {
+		result.Adjustments = append(result.Adjustments, Adjustment{
+			Description: fmt.Sprintf("Mínimo diario de %s horas", formatHours(p.DailyMinimum)),
+			Hours:       minimum,
+			Amount:      minimum * rate,
+		})
+	}
+
+	result.BilledHours += rounding + minimum
+}
+
+// applyTotalMinimum raises the billed hours to the total minimum, returning
+// the hours added
+func (c *Calculator) applyTotalMinimum(result *CalculationResult) float64 {
+	p := c.config.Pricing
+	if p.TotalMinimum <= 0 || result.BilledHours <= 0 || result.BilledHours >= p.TotalMinimum {
+		return 0
+	}
+
+	extra := p.TotalMinimum - result.BilledHours
+	c.trace("total minimum", "billed", result.BilledHours, "minimum", p.TotalMinimum)
+	result.Adjustments = append(result.Adjustments, Adjustment{
+		Description: fmt.Sprintf("Mínimo total de %s horas", formatHours(p.TotalMinimum)),
+		Hours:       extra,
+		Amount:      extra * result.HourlyRate,
+	})
+	result.BilledHours = p.TotalMinimum
+	return extra
+}
+
+// applyInvoicePricing applies the total minimum, volume tiers and discount
+// once to the combined result of several inputs, drawing the hours added by
+// the minimum from the retainer first, and recalculates taxes and total
+func (c *Calculator) applyInvoicePricing(result *CalculationResult) {
+	if c.config.Pricing == nil {
+		return
+	}
+	first := len(result.Adjustments)
+
+	if extra := c.applyTotalMinimum(result); extra > 0 {
+		amount := extra * result.HourlyRate
+		if r := result.Retainer; r != nil && c.retainer != nil {
+			drawn := c.retainer.draw(extra)
+			r.DrawnHours += drawn
+			r.RemainingHours = c.retainer.Balance()
+			r.OverageHours += extra - drawn
+			amount = (extra - drawn) * r.OverageRate
+			r.OverageAmount += amount
+		}
+		result.Adjustments[len(result.Adjustments)-1].Amount = amount
+		result.LaborAmount += amount
+	}
+	c.applyDiscounts(result)
+
+	for i := first; i < len(result.Adjustments); i++ {
+		result.Adjustments[i].Invoice = true
+	}
+
+	result.LaborTax = (result.LaborAmount + result.ItemsAmount) * c.config.TaxRate / 100
+	result.ExpenseTax = result.ExpenseTotal * c.config.ExpenseTaxRate / 100
+	result.TotalAmount = result.LaborAmount + result.ItemsAmount + result.ExpenseTotal +
+		result.LaborTax + result.ExpenseTax
+	c.trace("invoice pricing", "billed_hours", result.BilledHours, "labor", result.LaborAmount,
+		"labor_tax", result.LaborTax, "amount", result.TotalAmount)
+}
+
+// applyDiscounts discounts the billable labor. Volume tiers apply to the
+// hours beyond each threshold; the percentage discount to the whole labor.
+func (c *Calculator) applyDiscounts(result *CalculationResult) {
+	p := c.config.Pricing
+
+	hours, rate := result.BilledHours, result.HourlyRate
+	if result.Retainer != nil {
+		hours, rate = result.Retainer.OverageHours, result.Retainer.OverageRate
+	}
+	gross := result.LaborAmount
+	if len(result.Parts) > 0 && hours > 0 {
+		// Parts are billed at their own rates; tiers use the average one
+		rate = gross / hours
+	}
+
+	for i, tier := range p.VolumeTiers {
+		upper := hours
+		if i+1 < len(p.VolumeTiers) && p.VolumeTiers[i+1].AboveHours < upper {
+			upper = p.VolumeTiers[i+1].AboveHours
+		}
+		portion := upper - tier.AboveHours
+		if portion <= 0 || tier.Discount == 0 {
+			continue
+		}
+
+		amount := -portion * rate * tier.Discount / 100
+		c.trace("volume discount", "hours", portion, "above_hours", tier.AboveHours,
+			"hourly", rate, "discount", tier.Discount, "amount", amount)
+		result.Adjustments = append(result.Adjustments, Adjustment{
+			Description: fmt.Sprintf("Descuento por volumen %g%% (%s horas sobre %s)",
+				tier.Discount, formatHours(portion), formatHours(tier.AboveHours)),
+			Amount: amount,
+		})
+		result.LaborAmount += amount
+	}
+
+	if p.Discount > 0 && gross > 0 {
+		amount := -gross * p.Discount / 100
+		c.trace("discount", "labor", gross, "discount", p.Discount, "amount", amount)
+		result.Adjustments = append(result.Adjustments, Adjustment{
+			Description: fmt.Sprintf("Descuento %g%%", p.Discount),
+			Amount:      amount,
+		})
+		result.LaborAmount += amount
+	}
+}
+
+// formatAdjustment renders an adjustment for the result breakdown
+func formatAdjustment(adjustment Adjustment, currency string) string {
+	if adjustment.Hours != 0 {
+		sign := "+"
+		if adjustment.Hours < 0 {
+			sign = "-"
+		}
+		return fmt.Sprintf("  %s: %s%s horas = %s %.2f\n", adjustment.Description,
+			sign, formatHours(math.Abs(adjustment.Hours)), currency, adjustment.Amount)
+	}
+	return fmt.Sprintf("  %s: %s %.2f\n", adjustment.Description, currency, adjustment.Amount)
+}
@@ -0,0 +1,177 @@
+package calculator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestRoundIncrement(t *testing.T) {
+	tests := []struct {
+		hours     float64
+		increment float64
+		mode      string
+		expected  float64
+	}{
+		{1.1, 0.25, config.RoundUp, 1.25},
+		{1.25, 0.25, config.RoundUp, 1.25},
+		{0.7, 0.1, config.RoundUp, 0.7},
+		{1.1, 0.25, config.RoundNearest, 1.0},
+		{1.13, 0.25, config.RoundNearest, 1.25},
+		{3, 4, config.RoundUp, 4},
+		{1.1, 0.25, "", 1.25},
+	}
+
+	for _, test := range tests {
+		t.Run("", func(t *testing.T) {
+			result := roundIncrement(test.hours, test.increment, test.mode)
+			if result != test.expected {
+				t.Errorf("roundIncrement(%g, %g, %s) = %g, want %g", test.hours, test.increment, test.mode, result, test.expected)
+			}
+		})
+	}
+}
+
+func TestCalculatorCalculatePricing(t *testing.T) {
+	tests := []struct {
+		name           string
+		pricing        config.PricingRules
+		input          TimeInput
+		expectedBilled float64
+		expectedAdjs   int
+		expectedLabor  float64 // in hours at the regular rate
+	}{
+		{
+			name:           "15 minute increments rounded up",
+			pricing:        config.PricingRules{Increment: 0.25, Rounding: config.RoundUp},
+			input:          TimeInput{Hours: []float64{1.1, 2.3}},
+			expectedBilled: 3.75,
+			expectedAdjs:   1,
+			expectedLabor:  3.75,
+		},
+		{
+			name:           "daily minimum per entry",
+			pricing:        config.PricingRules{Increment: 0.25, DailyMinimum: 1},
+			input:          TimeInput{Hours: []float64{0.2, 3}},
+			expectedBilled: 4,
+			expectedAdjs:   2,
+			expectedLabor:  4,
+		},
+		{
+			name:           "total minimum",
+			pricing:        config.PricingRules{TotalMinimum: 10},
+			input:          TimeInput{Hours: []float64{4}},
+			expectedBilled: 10,
+			expectedAdjs:   1,
+			expectedLabor:  10,
+		},
+		{
+			name:           "volume discount beyond 120 hours",
+			pricing:        config.PricingRules{VolumeTiers: []config.VolumeTier{{AboveHours: 120, Discount: 5}}},
+			input:          TimeInput{Hours: []float64{160}},
+			expectedBilled: 160,
+			expectedAdjs:   1,
+			expectedLabor:  160 - 40*0.05,
+		},
+		{
+			name: "stacked volume tiers",
+			pricing: config.PricingRules{VolumeTiers: []config.VolumeTier{
+				{AboveHours: 100, Discount: 5},
+				{AboveHours: 150, Discount: 10},
+			}},
+			input:          TimeInput{Hours: []float64{200}},
+			expectedBilled: 200,
+			expectedAdjs:   2,
+			expectedLabor:  200 - 50*0.05 - 50*0.10,
+		},
+		{
+			name:           "percentage discount",
+			pricing:        config.PricingRules{Discount: 10},
+			input:          TimeInput{Days: []int{5}},
+			expectedBilled: 40,
+			expectedAdjs:   1,
+			expectedLabor:  36,
+		},
+		{
+			name:           "no adjustments needed",
+			pricing:        config.PricingRules{Increment: 0.25, DailyMinimum: 1, TotalMinimum: 8},
+			input:          TimeInput{Hours: []float64{8}},
+			expectedBilled: 8,
+			expectedAdjs:   0,
+			expectedLabor:  8,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := config.NewBillingConfig()
+			pricing := test.pricing
+			cfg.Pricing = &pricing
+			calc := NewCalculator(cfg)
+
+			result, err := calc.Calculate(test.input, "U$S")
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error: %v", err)
+			}
+
+			if result.BilledHours != test.expectedBilled {
+				t.Errorf("BilledHours = %g, want %g", result.BilledHours, test.expectedBilled)
+			}
+			if len(result.Adjustments) != test.expectedAdjs {
+				t.Errorf("Adjustments = %+v, want %d entries", result.Adjustments, test.expectedAdjs)
+			}
+			want := test.expectedLabor * cfg.HourlyRate
+			if diff := result.LaborAmount - want; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("LaborAmount = %.4f, want %.4f", result.LaborAmount, want)
+			}
+		})
+	}
+}
+
+func TestCalculatorFormatResultPricing(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	cfg.Pricing = &config.PricingRules{
+		Increment:    0.25,
+		DailyMinimum: 1,
+		VolumeTiers:  []config.VolumeTier{{AboveHours: 2, Discount: 50}},
+	}
+	calc := NewCalculator(cfg)
+
+	result, err := calc.Calculate(TimeInput{Hours: []float64{0.5, 2.1}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	output := calc.FormatResult(result)
+	expectedSubstrings := []string{
+		"Ajustes:",
+		"Redondeo a incrementos de 0.25 horas (hacia arriba): +0.15 horas = U$S 2.06",
+		"Mínimo diario de 1 horas: +0.5 horas = U$S 6.88",
+		"Descuento por volumen 50% (1.25 horas sobre 2): U$S -8.59",
+		"Total de horas: 2.6",
+		"Horas facturadas: 3.25",
+	}
+
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() output missing expected substring: %s", expected)
+		}
+	}
+}
+
+func TestPricingRulesValidate(t *testing.T) {
+	invalid := []config.PricingRules{
+		{Increment: -1},
+		{Rounding: "down"},
+		{DailyMinimum: -1},
+		{Discount: 120},
+		{VolumeTiers: []config.VolumeTier{{AboveHours: 120, Discount: 5}, {AboveHours: 100, Discount: 10}}},
+	}
+
+	for _, rules := range invalid {
+		if err := rules.Validate(); err == nil {
+			t.Errorf("Validate(%+v) expected error, got nil", rules)
+		}
+	}
+}
@@ -0,0 +1,370 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// Subtotals holds the time and amounts billed to one project or person
+type Subtotals struct {
+	TotalWeeks   int     `json:"total_weeks"`
+	TotalDays    int     `json:"total_days"`
+	TotalHours   float64 `json:"total_hours"`
+	TotalTime    float64 `json:"total_time"`
+	BilledHours  float64 `json:"billed_hours"`
+	LaborAmount  float64 `json:"labor_amount"`
+	ItemsAmount  float64 `json:"items_amount"`
+	ExpenseTotal float64 `json:"expense_total"`
+	LaborTax     float64 `json:"labor_tax"`
+	ExpenseTax   float64 `json:"expense_tax"`
+	Subtotal     float64 `json:"subtotal"` // labor, items and expenses before taxes
+}
+
+// add adds the totals of a partial result
+func (s *Subtotals) add(part *CalculationResult) {
+	s.TotalWeeks += part.TotalWeeks
+	s.TotalDays += part.TotalDays
+	s.TotalHours += part.TotalHours
+	s.TotalTime += part.TotalTime
+	s.BilledHours += part.BilledHours
+	s.LaborAmount += part.LaborAmount
+	s.ItemsAmount += part.ItemsAmount
+	s.ExpenseTotal += part.ExpenseTotal
+	s.LaborTax += part.LaborTax
+	s.ExpenseTax += part.ExpenseTax
+	s.Subtotal += part.LaborAmount + part.ItemsAmount + part.ExpenseTotal
+}
+
+// ProjectResult holds the part of a calculation billed to one project
+type ProjectResult struct {
+	Project      string  `json:"project"`
+	Currency     string  `json:"currency,omitempty"`      // currency the project's rate is quoted in
+	ExchangeRate float64 `json:"exchange_rate,omitempty"` // converts the project's rate into the invoice currency
+	HourlyRate   float64 `json:"hourly_rate"`             // in the invoice currency, 0 with mixed rates
+	MixedRates   bool    `json:"mixed_rates,omitempty"`   // billed at several rates, listed in the parts
+	Subtotals
+}
+
+// PersonResult holds the part of a calculation billed for one team member
+type PersonResult struct {
+	Person     string  `json:"person"`
+	Role       string  `json:"role,omitempty"`
+	HourlyRate float64 `json:"hourly_rate"`           // 0 with mixed rates
+	MixedRates bool    `json:"mixed_rates,omitempty"` // billed at several rates, listed in the parts
+	Subtotals
+}
+
+// PartResult holds the part of a calculation billed to one project and
+// team member, with the rate and schedule of both
+type PartResult struct {
+	Project    string  `json:"project,omitempty"`
+	Person     string  `json:"person,omitempty"`
+	HourlyRate float64 `json:"hourly_rate"` // in the invoice currency, 0 with mixed rates
+	MixedRates bool    `json:"mixed_rates,omitempty"`
+	Subtotals
+}
+
+// mergeRate keeps the rate shared by every input added to an entry, or
+// marks the entry as billed at mixed rates
+func mergeRate(rate *float64, mixed *bool, partRate float64) {
+	if !*mixed && math.Abs(*rate-partRate) > 1e-9 {
+		*rate, *mixed = 0, true
+	}
+}
+
+// CalculateProjects calculates several inputs, each tagged with a project,
+// a team member or both, into one result. Each input is billed with the
+// rate, schedule and currency of its person and project, converted into the
+// invoice currency; the result lists a subtotal per project, per person and
+// per pair of both. A project's rate takes precedence over the person's.
+// The total minimum, volume tiers and discount apply once, to the combined
+// labor. A single untagged input is the same as Calculate.
+func (c *Calculator) CalculateProjects(inputs []TimeInput, currency string) (*CalculationResult, error) {
+	if len(inputs) == 1 && inputs[0].Project == "" && inputs[0].Person == "" {
+		return c.Calculate(inputs[0], currency)
+	}
+
+	result := &CalculationResult{Currency: currency}
+	reference := c.referenceRates(nil)
+	result.HourlyRate = reference.Hourly
+	result.WeeksStrategy = c.config.Strategy()
+	result.WeeksPerMonth = reference.WeeksPerMonth
+
+	byProject, byPerson := false, false
+	for _, input := range inputs {
+		byProject = byProject || input.Project != ""
+		byPerson = byPerson || input.Person != ""
+	}
+
+	for _, input := range inputs {
+		calc, project, err := c.forInput(input, currency)
+		if err != nil {
+			return nil, err
+		}
+		part, err := calc.calculate(input, currency, false)
+		c.retainer = calc.retainer
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", inputLabel(input.Project, input.Person), err)
+		}
+		project.HourlyRate = part.HourlyRate
+		c.addPart(result, part, input)
+
+		entry := findPart(result, PartResult{Project: input.Project, Person: input.Person, HourlyRate: part.HourlyRate})
+		mergeRate(&entry.HourlyRate, &entry.MixedRates, part.HourlyRate)
+		entry.add(part)
+		if byProject {
+			entry := findProject(result, project)
+			mergeRate(&entry.HourlyRate, &entry.MixedRates, part.HourlyRate)
+			entry.add(part)
+		}
+		if byPerson {
+			entry := findPerson(result, PersonResult{
+				Person:     input.Person,
+				Role:       c.config.Team[input.Person].Role,
+				HourlyRate: part.HourlyRate,
+			})
+			mergeRate(&entry.HourlyRate, &entry.MixedRates, part.HourlyRate)
+			entry.add(part)
+		}
+		c.trace("input subtotal", "project", input.Project, "person", input.Person,
+			"hourly", part.HourlyRate, "billed_hours", part.BilledHours, "labor", part.LaborAmount,
+			"items", part.ItemsAmount, "expenses", part.ExpenseTotal,
+			"subtotal", part.LaborAmount+part.ItemsAmount+part.ExpenseTotal)
+	}
+
+	// Minimums and discounts apply to the invoice as a whole
+	c.applyInvoicePricing(result)
+	return result, nil
+}
+
+// inputConfig returns the configuration an input tagged with a project and
+// a person is billed with, in the currency the project's rate is quoted in
+func (c *Calculator) inputConfig(project, person string) (*config.BillingConfig, error) {
+	cfg, err := c.config.ForPerson(person)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.ForProject(project)
+}
+
+// forInput returns a calculator billing an input in currency and the
+// project's entry of the result
+func (c *Calculator) forInput(input TimeInput, currency string) (*Calculator, ProjectResult, error) {
+	project := ProjectResult{Project: input.Project}
+	cfg, err := c.inputConfig(input.Project, input.Person)
+	if err != nil {
+		return nil, project, err
+	}
+
+	if quoted := c.config.Projects[input.Project].Currency; quoted != "" {
+		rate, err := c.config.ExchangeRate(quoted, currency)
+		if err != nil {
+			return nil, project, fmt.Errorf("project %s: %w", input.Project, err)
+		}
+		project.Currency, project.ExchangeRate = quoted, rate
+		if rate != 1 {
+			cfg.ScaleRates(rate)
+			c.trace("project rate conversion", "project", input.Project, "from", quoted, "to", currency,
+				"exchange_rate", rate, "hourly", cfg.HourlyRate)
+		}
+	}
+
+	calc := &Calculator{config: cfg, retainer: c.retainer, clock: c.clock, logger: c.logger}
+	return calc, project, nil
+}
+
+// addPart adds the result of one input to the combined result, tagging its
+// lines with the input's project and person
+func (c *Calculator) addPart(result, part *CalculationResult, input TimeInput) {
+	for i := range part.MonthDetails {
+		part.MonthDetails[i].Project, part.MonthDetails[i].Person = input.Project, input.Person
+	}
+	for i := range part.Adjustments {
+		part.Adjustments[i].Project, part.Adjustments[i].Person = input.Project, input.Person
+	}
+	for i := range part.LineItems {
+		part.LineItems[i].Project, part.LineItems[i].Person = input.Project, input.Person
+	}
+	for i := range part.Expenses {
+		part.Expenses[i].Project, part.Expenses[i].Person = input.Project, input.Person
+	}
+	result.MonthDetails = append(result.MonthDetails, part.MonthDetails...)
+	result.Adjustments = append(result.Adjustments, part.Adjustments...)
+	result.LineItems = append(result.LineItems, part.LineItems...)
+	result.Expenses = append(result.Expenses, part.Expenses...)
+
+	result.TotalWeeks += part.TotalWeeks
+	result.TotalDays += part.TotalDays
+	result.TotalHours += part.TotalHours
+	result.TotalTime += part.TotalTime
+	result.BilledHours += part.BilledHours
+	result.LaborAmount += part.LaborAmount
+	result.ItemsAmount += part.ItemsAmount
+	result.ExpenseTotal += part.ExpenseTotal
+	result.LaborTax += part.LaborTax
+	result.ExpenseTax += part.ExpenseTax
+	result.TotalAmount += part.TotalAmount
+
+	if r := part.Retainer; r != nil {
+		if result.Retainer == nil {
+			result.Retainer = &RetainerSummary{OpeningHours: r.OpeningHours, OverageRate: r.OverageRate}
+		}
+		result.Retainer.DrawnHours += r.DrawnHours
+		result.Retainer.ExpiredHours += r.ExpiredHours
+		result.Retainer.RemainingHours = r.RemainingHours
+		result.Retainer.OverageHours += r.OverageHours
+		result.Retainer.OverageAmount += r.OverageAmount
+	}
+}
+
+// findProject returns the entry of a project in the result, adding it when
+// the project has none yet
+func findProject(result *CalculationResult, project ProjectResult) *ProjectResult {
+	for i := range result.Projects {
+		if result.Projects[i].Project == project.Project {
+			return &result.Projects[i]
+		}
+	}
+	result.Projects = append(result.Projects, project)
+	return &result.Projects[len(result.Projects)-1]
+}
+
+// findPart returns the entry of a pair of project and person in the result,
+// adding it when the pair has none yet
+func findPart(result *CalculationResult, part PartResult) *PartResult {
+	for i := range result.Parts {
+		if result.Parts[i].Project == part.Project && result.Parts[i].Person == part.Person {
+			return &result.Parts[i]
+		}
+	}
+	result.Parts = append(result.Parts, part)
+	return &result.Parts[len(result.Parts)-1]
+}
+
+// findPerson returns the entry of a person in the result, adding it when
+// the person has none yet
+func findPerson(result *CalculationResult, person PersonResult) *PersonResult {
+	for i := range result.People {
+		if result.People[i].Person == person.Person {
+			return &result.People[i]
+		}
+	}
+	result.People = append(result.People, person)
+	return &result.People[len(result.People)-1]
+}
+
+// resultPart rebuilds the part of a combined result whose lines are tagged
+// with the project and person of part
+func resultPart(result *CalculationResult, part PartResult) *CalculationResult {
+	keep := func(project, person string) bool {
+		return project == part.Project && person == part.Person
+	}
+	sub := &CalculationResult{
+		TotalWeeks:    part.TotalWeeks,
+		TotalDays:     part.TotalDays,
+		TotalHours:    part.TotalHours,
+		TotalTime:     part.TotalTime,
+		BilledHours:   part.BilledHours,
+		LaborAmount:   part.LaborAmount,
+		ItemsAmount:   part.ItemsAmount,
+		ExpenseTotal:  part.ExpenseTotal,
+		Currency:      result.Currency,
+		HourlyRate:    part.HourlyRate,
+		WeeksStrategy: result.WeeksStrategy,
+	}
+	for _, monthInfo := range result.MonthDetails {
+		if keep(monthInfo.Project, monthInfo.Person) {
+			sub.MonthDetails = append(sub.MonthDetails, monthInfo)
+		}
+	}
+	for _, adjustment := range result.Adjustments {
+		if !adjustment.Invoice && keep(adjustment.Project, adjustment.Person) {
+			sub.Adjustments = append(sub.Adjustments, adjustment)
+		}
+	}
+	for _, line := range result.LineItems {
+		if keep(line.Project, line.Person) {
+			sub.LineItems = append(sub.LineItems, line)
+		}
+	}
+	for _, line := range result.Expenses {
+		if keep(line.Project, line.Person) {
+			sub.Expenses = append(sub.Expenses, line)
+		}
+	}
+	return sub
+}
+
+// projectLabel names a project for display
+func projectLabel(name string) string {
+	if name == "" {
+		return "(sin proyecto)"
+	}
+	return name
+}
+
+// personLabel names a team member for display
+func personLabel(name string) string {
+	if name == "" {
+		return "(sin persona)"
+	}
+	return name
+}
+
+// inputLabel names the project and person of an input in errors
+func inputLabel(project, person string) string {
+	switch {
+	case project != "" && person != "":
+		return fmt.Sprintf("project %s, person %s", project, person)
+	case person != "":
+		return "person " + person
+	default:
+		return "project " + projectLabel(project)
+	}
+}
+
+// partCalculator returns a calculator with the configuration a part was
+// billed with, falling back to the profile when it cannot be resolved
+func (c *Calculator) partCalculator(part PartResult, currency string) *Calculator {
+	calc, _, err := c.forInput(TimeInput{Project: part.Project, Person: part.Person}, currency)
+	if err != nil {
+		return &Calculator{config: c.config}
+	}
+	return calc
+}
+
+// partHeading names a part in the heading of its breakdown
+func partHeading(part PartResult, role string, byPerson bool) string {
+	var heading string
+	switch {
+	case part.Person != "":
+		heading = "Persona " + part.Person
+		if role != "" {
+			heading += ", " + role
+		}
+	case byPerson:
+		heading = "Sin persona"
+	}
+	switch {
+	case part.Project == "" && heading == "":
+		return "Sin proyecto"
+	case part.Project == "":
+		return heading
+	case heading == "":
+		return "Proyecto " + part.Project
+	}
+	return heading + " en proyecto " + part.Project
+}
+
+// partLabel names a part for display
+func partLabel(part PartResult, byPerson bool) string {
+	switch {
+	case part.Person != "" && part.Project != "":
+		return part.Person + " en " + part.Project
+	case part.Person != "" || byPerson:
+		return personLabel(part.Person)
+	}
+	return projectLabel(part.Project)
+}
@@ -0,0 +1,268 @@
+package calculator
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func newProjectCalculator(t *testing.T) *Calculator {
+	t.Helper()
+	cfg := config.NewBillingConfig()
+	if err := cfg.SetRate(config.AnchorHourly, 40); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.Projects = map[string]config.Project{
+		"web": {HourlyRate: 50},
+		"app": {HourlyRate: 60, HoursPerDay: 6},
+		"eu":  {HourlyRate: 100, Currency: "EUR"},
+	}
+	if err := cfg.SetExchangeRate("EUR", "USD", 1.1); err != nil {
+		t.Fatalf("SetExchangeRate() unexpected error: %v", err)
+	}
+	return NewCalculator(cfg)
+}
+
+func TestCalculateProjects(t *testing.T) {
+	calc := newProjectCalculator(t)
+	inputs := []TimeInput{
+		{Hours: []float64{3}},
+		{Project: "web", Days: []int{2}, Items: []ItemInput{{Type: ItemFixed, Description: "Setup", Amount: 100}}},
+		{Project: "app", Days: []int{2}},
+		{Project: "eu", Hours: []float64{10}},
+	}
+
+	result, err := calc.CalculateProjects(inputs, "USD")
+	if err != nil {
+		t.Fatalf("CalculateProjects() unexpected error: %v", err)
+	}
+
+	want := []struct {
+		project    string
+		hourlyRate float64
+		hours      float64
+		subtotal   float64
+	}{
+		{"", 40, 3, 120},
+		{"web", 50, 16, 900},
+		{"app", 60, 12, 720},
+		{"eu", 110, 10, 1100},
+	}
+	if len(result.Projects) != len(want) {
+		t.Fatalf("CalculateProjects() returned %d projects, want %d", len(result.Projects), len(want))
+	}
+	for i, w := range want {
+		project := result.Projects[i]
+		if project.Project != w.project || math.Abs(project.HourlyRate-w.hourlyRate) > 0.001 ||
+			project.TotalTime != w.hours || math.Abs(project.Subtotal-w.subtotal) > 0.001 {
+			t.Errorf("Projects[%d] = %s %.2f/h %.2f h %.2f, want %s %.2f/h %.2f h %.2f", i,
+				project.Project, project.HourlyRate, project.TotalTime, project.Subtotal,
+				w.project, w.hourlyRate, w.hours, w.subtotal)
+		}
+	}
+
+	if result.TotalTime != 41 || math.Abs(result.TotalAmount-2840) > 0.001 {
+		t.Errorf("CalculateProjects() = %.2f h, %.2f, want 41.00 h, 2840.00", result.TotalTime, result.TotalAmount)
+	}
+	if len(result.LineItems) != 1 || result.LineItems[0].Project != "web" {
+		t.Errorf("CalculateProjects() line items = %+v, want Setup tagged web", result.LineItems)
+	}
+
+	output := calc.FormatResult(result)
+	for _, expected := range []string{
+		"--- Sin proyecto (USD 40.00 por hora) ---",
+		"--- Proyecto app (USD 60.00 por hora) ---",
+		"Días: 2 × 6 horas = 12 horas",
+		"Subtotal web: USD 900.00",
+		"Subtotal eu: USD 1100.00",
+		"TOTAL A FACTURAR: USD 2840.00",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() missing %q in:\n%s", expected, output)
+		}
+	}
+}
+
+func TestCalculateProjectsSingleInput(t *testing.T) {
+	calc := newProjectCalculator(t)
+
+	result, err := calc.CalculateProjects([]TimeInput{{Hours: []float64{10}}}, "USD")
+	if err != nil {
+		t.Fatalf("CalculateProjects() unexpected error: %v", err)
+	}
+	if len(result.Projects) != 0 || result.TotalAmount != 400 {
+		t.Errorf("CalculateProjects() = %d projects, %.2f, want no projects, 400.00", len(result.Projects), result.TotalAmount)
+	}
+
+	if _, err := calc.CalculateProjects([]TimeInput{{Project: "mobile", Hours: []float64{1}}}, "USD"); err == nil {
+		t.Error("CalculateProjects() expected error for unknown project, got nil")
+	}
+}
+
+func TestCalculateProjectsTeam(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	if err := cfg.SetRate(config.AnchorHourly, 40); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.Team = map[string]config.Member{
+		"ana":  {Role: "Lead", RateAnchor: config.AnchorDaily, RateAmount: 480},
+		"luis": {Role: "Dev", RateAmount: 30},
+	}
+	calc := NewCalculator(cfg)
+	inputs := []TimeInput{
+		{Person: "ana", Days: []int{10}},
+		{Person: "luis", Hours: []float64{32}},
+	}
+
+	result, err := calc.CalculateProjects(inputs, "USD")
+	if err != nil {
+		t.Fatalf("CalculateProjects() unexpected error: %v", err)
+	}
+
+	want := []struct {
+		person     string
+		role       string
+		hourlyRate float64
+		hours      float64
+		subtotal   float64
+	}{
+		{"ana", "Lead", 60, 80, 4800},
+		{"luis", "Dev", 30, 32, 960},
+	}
+	if len(result.People) != len(want) || len(result.Projects) != 0 {
+		t.Fatalf("CalculateProjects() returned %d people, %d projects, want %d people", len(result.People), len(result.Projects), len(want))
+	}
+	for i, w := range want {
+		person := result.People[i]
+		if person.Person != w.person || person.Role != w.role || math.Abs(person.HourlyRate-w.hourlyRate) > 0.001 ||
+			person.TotalTime != w.hours || math.Abs(person.Subtotal-w.subtotal) > 0.001 {
+			t.Errorf("People[%d] = %s %s %.2f/h %.2f h %.2f, want %s %s %.2f/h %.2f h %.2f", i,
+				person.Person, person.Role, person.HourlyRate, person.TotalTime, person.Subtotal,
+				w.person, w.role, w.hourlyRate, w.hours, w.subtotal)
+		}
+	}
+	if result.TotalTime != 112 || math.Abs(result.TotalAmount-5760) > 0.001 {
+		t.Errorf("CalculateProjects() = %.2f h, %.2f, want 112.00 h, 5760.00", result.TotalTime, result.TotalAmount)
+	}
+
+	output := calc.FormatResult(result)
+	for _, expected := range []string{
+		"--- Persona ana, Lead (USD 60.00 por hora) ---",
+		"--- Persona luis, Dev (USD 30.00 por hora) ---",
+		"Subtotal ana: USD 4800.00",
+		"Subtotal luis: USD 960.00",
+		"TOTAL A FACTURAR: USD 5760.00",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() missing %q in:\n%s", expected, output)
+		}
+	}
+
+	csv := calc.FormatCSV(result)
+	for _, expected := range []string{"time,days ana,80,60.00,4800.00", "time,hours luis,32,30.00,960.00"} {
+		if !strings.Contains(csv, expected) {
+			t.Errorf("FormatCSV() missing %q in:\n%s", expected, csv)
+		}
+	}
+
+	if _, err := calc.CalculateProjects([]TimeInput{{Person: "bob", Hours: []float64{1}}}, "USD"); err == nil {
+		t.Error("CalculateProjects() expected error for unknown team member, got nil")
+	}
+}
+
+func TestCalculateProjectsTeamOnProjects(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	if err := cfg.SetRate(config.AnchorHourly, 40); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.Projects = map[string]config.Project{"app": {HourlyRate: 60, HoursPerDay: 6}}
+	cfg.Team = map[string]config.Member{"luis": {Role: "Dev", HourlyRate: 30}}
+	calc := NewCalculator(cfg)
+	inputs := []TimeInput{
+		{Person: "luis", Hours: []float64{10}, Days: []int{1}},
+		{Project: "app", Person: "luis", Days: []int{2}},
+	}
+
+	result, err := calc.CalculateProjects(inputs, "USD")
+	if err != nil {
+		t.Fatalf("CalculateProjects() unexpected error: %v", err)
+	}
+
+	if len(result.People) != 1 || !result.People[0].MixedRates || result.People[0].HourlyRate != 0 {
+		t.Errorf("People = %+v, want luis at mixed rates", result.People)
+	}
+	if len(result.Parts) != 2 || result.Parts[0].HourlyRate != 30 || result.Parts[1].HourlyRate != 60 ||
+		result.Parts[1].TotalTime != 12 {
+		t.Errorf("Parts = %+v, want luis at 30.00/h and 12 h on app at 60.00/h", result.Parts)
+	}
+	if math.Abs(result.TotalAmount-1260) > 0.001 {
+		t.Errorf("CalculateProjects() total = %.2f, want 1260.00", result.TotalAmount)
+	}
+
+	output := calc.FormatResult(result)
+	for _, expected := range []string{
+		"--- Persona luis, Dev (USD 30.00 por hora) ---",
+		"--- Persona luis, Dev en proyecto app (USD 60.00 por hora) ---",
+		"Días: 2 × 6 horas = 12 horas",
+		"Subtotal luis en app: USD 720.00",
+		"Subtotal luis: USD 1260.00",
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() missing %q in:\n%s", expected, output)
+		}
+	}
+
+	csv := calc.FormatCSV(result)
+	if !strings.Contains(csv, "time,days luis en app,12,60.00,720.00") {
+		t.Errorf("FormatCSV() missing the app days of luis in:\n%s", csv)
+	}
+}
+
+func TestCalculateProjectsPricesInvoiceOnce(t *testing.T) {
+	newCalc := func(pricing *config.PricingRules) *Calculator {
+		cfg := config.NewBillingConfig()
+		if err := cfg.SetRate(config.AnchorHourly, 40); err != nil {
+			t.Fatalf("SetRate() unexpected error: %v", err)
+		}
+		cfg.Pricing = pricing
+		cfg.Team = map[string]config.Member{"ana": {HourlyRate: 60}, "luis": {HourlyRate: 30}}
+		return NewCalculator(cfg)
+	}
+
+	tests := []struct {
+		name        string
+		pricing     *config.PricingRules
+		hours       float64
+		billedHours float64
+		total       float64
+	}{
+		{"total minimum", &config.PricingRules{TotalMinimum: 10}, 2, 10, 2*60 + 2*30 + 6*40},
+		{"volume tier", &config.PricingRules{VolumeTiers: []config.VolumeTier{{AboveHours: 120, Discount: 5}}}, 100, 200, 9000 - 80*45*0.05},
+		{"discount", &config.PricingRules{Discount: 10}, 10, 20, 900 * 0.9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc := newCalc(tt.pricing)
+			inputs := []TimeInput{
+				{Person: "ana", Hours: []float64{tt.hours}},
+				{Person: "luis", Hours: []float64{tt.hours}},
+			}
+			result, err := calc.CalculateProjects(inputs, "USD")
+			if err != nil {
+				t.Fatalf("CalculateProjects() unexpected error: %v", err)
+			}
+			if result.BilledHours != tt.billedHours || math.Abs(result.TotalAmount-tt.total) > 0.001 {
+				t.Errorf("CalculateProjects() = %.2f h, %.2f, want %.2f h, %.2f",
+					result.BilledHours, result.TotalAmount, tt.billedHours, tt.total)
+			}
+			if len(result.Adjustments) != 1 || !result.Adjustments[0].Invoice {
+				t.Errorf("Adjustments = %+v, want one invoice adjustment", result.Adjustments)
+			}
+			if output := calc.FormatResult(result); !strings.Contains(output, "Ajustes de la factura:") {
+				t.Errorf("FormatResult() missing the invoice adjustments in:\n%s", output)
+			}
+		})
+	}
+}
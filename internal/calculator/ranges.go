@@ -0,0 +1,90 @@
+package calculator
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateLayout is the format of range boundaries
+const dateLayout = "2006-01-02"
+
+// DateRange is a billed period between two dates, both inclusive
+type DateRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// ParseDateRange parses --from/--to dates in YYYY-MM-DD format. When
+// exclusive is set the to date itself is not billed.
+func ParseDateRange(from, to string, exclusive bool) (DateRange, error) {
+	var r DateRange
+
+	start, err := time.Parse(dateLayout, from)
+	if err != nil {
+		return r, inputError(ErrInvalidInput, "from", from, "invalid start date: %s (use YYYY-MM-DD)", from)
+	}
+	end, err := time.Parse(dateLayout, to)
+	if err != nil {
+		return r, inputError(ErrInvalidInput, "to", to, "invalid end date: %s (use YYYY-MM-DD)", to)
+	}
+	if exclusive {
+		end = end.AddDate(0, 0, -1)
+	}
+
+	r = DateRange{From: start, To: end}
+	return r, validateRange(r)
+}
+
+// validateRange checks that a range covers at least one day
+func validateRange(r DateRange) error {
+	if r.To.Before(r.From) {
+		return inputError(ErrInvalidInput, "to", r.To.Format(dateLayout), "date range is empty: %s to %s", r.From.Format(dateLayout), r.To.Format(dateLayout))
+	}
+	return nil
+}
+
+// String renders the range as FROM..TO
+func (r DateRange) String() string {
+	return r.From.Format(dateLayout) + ".." + r.To.Format(dateLayout)
+}
+
+// splitRange breaks a date range into one segment per calendar month. Every
+// segment counts only the workdays it contains.
+func (c *Calculator) splitRange(r DateRange) []MonthInfo {
+	var segments []MonthInfo
+
+	for start := r.From; !start.After(r.To); {
+		end := time.Date(start.Year(), start.Month()+1, 0, 0, 0, 0, 0, time.UTC)
+		if end.After(r.To) {
+			end = r.To
+		}
+
+		segments = append(segments, MonthInfo{
+			Input: start.Format(dateLayout) + ".." + end.Format(dateLayout),
+			Days:  c.config.WorkingDays(start, end),
+			Year:  start.Year(),
+			Month: int(start.Month()),
+			From:  start.Format(dateLayout),
+			To:    end.Format(dateLayout),
+		})
+
+		start = end.AddDate(0, 0, 1)
+	}
+
+	return segments
+}
+
+// formatSegment renders a partial-month segment of a date range, with its
+// rate and amount when months are billed at different rates
+func (c *Calculator) formatSegment(monthInfo MonthInfo, currency string, showRate bool) string {
+	from, _ := time.Parse(dateLayout, monthInfo.From)
+	to, _ := time.Parse(dateLayout, monthInfo.To)
+
+	line := fmt.Sprintf("    %04d-%02d (%d al %d): %d días laborables × %d horas = %s horas",
+		monthInfo.Year, monthInfo.Month, from.Day(), to.Day(), monthInfo.Days, c.config.HoursPerDay,
+		formatHours(monthInfo.Hours))
+	if showRate {
+		line += fmt.Sprintf(" × %s %.2f = %s %.2f", currency, monthInfo.HourlyRate, currency, monthInfo.Amount)
+	}
+	return line + "\n"
+}
@@ -0,0 +1,126 @@
+package calculator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestParseDateRange(t *testing.T) {
+	tests := []struct {
+		from, to     string
+		exclusive    bool
+		expectedFrom string
+		expectedTo   string
+		expectError  bool
+	}{
+		{"2026-09-12", "2026-10-11", false, "2026-09-12", "2026-10-11", false},
+		{"2026-09-12", "2026-10-11", true, "2026-09-12", "2026-10-10", false},
+		{"2026-09-12", "2026-09-12", false, "2026-09-12", "2026-09-12", false},
+		{"2026-09-12", "2026-09-12", true, "", "", true}, // empty range
+		{"2026-09-12", "2026-09-01", false, "", "", true},
+		{"2026-02-30", "2026-03-01", false, "", "", true},
+		{"2026-09-12", "11/10/2026", false, "", "", true},
+		{"", "2026-10-11", false, "", "", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.from+".."+test.to, func(t *testing.T) {
+			r, err := ParseDateRange(test.from, test.to, test.exclusive)
+
+			if test.expectError {
+				if err == nil {
+					t.Errorf("ParseDateRange(%s, %s, %t) expected error, got nil", test.from, test.to, test.exclusive)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseDateRange(%s, %s, %t) unexpected error: %v", test.from, test.to, test.exclusive, err)
+				return
+			}
+
+			if want := test.expectedFrom + ".." + test.expectedTo; r.String() != want {
+				t.Errorf("ParseDateRange(%s, %s, %t) = %s, want %s", test.from, test.to, test.exclusive, r, want)
+			}
+		})
+	}
+}
+
+func TestCalculatorCalculateDateRange(t *testing.T) {
+	calc := NewCalculator(config.NewBillingConfig())
+
+	r, err := ParseDateRange("2026-09-12", "2026-11-03", false)
+	if err != nil {
+		t.Fatalf("ParseDateRange() unexpected error: %v", err)
+	}
+
+	result, err := calc.Calculate(TimeInput{Ranges: []DateRange{r}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		month    int
+		from, to string
+		days     int
+	}{
+		{9, "2026-09-12", "2026-09-30", 13},
+		{10, "2026-10-01", "2026-10-31", 22},
+		{11, "2026-11-01", "2026-11-03", 2},
+	}
+
+	if len(result.MonthDetails) != len(expected) {
+		t.Fatalf("MonthDetails has %d segments, want %d", len(result.MonthDetails), len(expected))
+	}
+	for i, want := range expected {
+		segment := result.MonthDetails[i]
+		if segment.Year != 2026 || segment.Month != want.month {
+			t.Errorf("segment %d month = %d-%02d, want 2026-%02d", i, segment.Year, segment.Month, want.month)
+		}
+		if segment.From != want.from || segment.To != want.to {
+			t.Errorf("segment %d = %s..%s, want %s..%s", i, segment.From, segment.To, want.from, want.to)
+		}
+		if segment.Days != want.days {
+			t.Errorf("segment %d Days = %d, want %d", i, segment.Days, want.days)
+		}
+	}
+
+	if result.TotalTime != 37*8 {
+		t.Errorf("TotalTime = %g, want %d", result.TotalTime, 37*8)
+	}
+
+	output := calc.FormatResult(result)
+	expectedSubstrings := []string{
+		"Rango de fechas:",
+		"2026-09 (12 al 30): 13 días laborables × 8 horas = 104 horas",
+		"2026-11 (1 al 3): 2 días laborables × 8 horas = 16 horas",
+	}
+
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() output missing expected substring: %s", expected)
+		}
+	}
+	if strings.Contains(output, "Meses:") {
+		t.Error("FormatResult() should not list date range segments as whole months")
+	}
+}
+
+func TestCalculatorDateRangeWorkDays(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	cfg.WorkDays = 6 // Monday to Saturday
+	calc := NewCalculator(cfg)
+
+	// 2026-09-12 is a Saturday, 2026-09-13 a Sunday
+	r, _ := ParseDateRange("2026-09-12", "2026-09-14", false)
+	result, err := calc.Calculate(TimeInput{Ranges: []DateRange{r}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	if result.MonthDetails[0].Days != 2 {
+		t.Errorf("Days = %d, want 2", result.MonthDetails[0].Days)
+	}
+}
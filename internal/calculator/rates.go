@@ -0,0 +1,31 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// referenceRates returns the rates applied to hours, days and weeks that are
+// not tied to a billed month: those of the first billed month, or of the
+// current month when no month was given, at the rate in effect then
+func (c *Calculator) referenceRates(months []MonthInfo) config.Rates {
+	if len(months) > 0 {
+		return c.ratesFor(months[0])
+	}
+	return c.ratesAt(c.now())
+}
+
+// weeksLabel describes the weeks-per-month strategy for display
+func weeksLabel(strategy string, weeks float64) string {
+	switch strategy {
+	case config.WeeksAverage:
+		return fmt.Sprintf("%s (promedio 52/12)", formatHours(weeks))
+	case config.WeeksCalendar:
+		return fmt.Sprintf("%s (semanas reales del mes)", formatHours(weeks))
+	case config.WeeksWorking:
+		return fmt.Sprintf("%s (horas laborables del mes)", formatHours(weeks))
+	default:
+		return fmt.Sprintf("%s (fijo)", formatHours(weeks))
+	}
+}
@@ -0,0 +1,211 @@
+package calculator
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// RetainerLot is a batch of prepaid hours added to the bank in a period
+type RetainerLot struct {
+	Period string  `json:"period"`
+	Hours  float64 `json:"hours"`
+}
+
+// RetainerState is the running balance of a retainer bank between calculations
+type RetainerState struct {
+	Period string        `json:"period"`
+	Lots   []RetainerLot `json:"lots"`
+}
+
+// RetainerSummary reports how a calculation drew on the prepaid hour bank
+type RetainerSummary struct {
+	OpeningHours   float64 `json:"opening_hours"`
+	DrawnHours     float64 `json:"drawn_hours"`
+	ExpiredHours   float64 `json:"expired_hours"`
+	RemainingHours float64 `json:"remaining_hours"`
+	OverageHours   float64 `json:"overage_hours"`
+	OverageRate    float64 `json:"overage_rate"`
+	OverageAmount  float64 `json:"overage_amount"`
+}
+
+// Balance returns the hours left in the bank
+func (s *RetainerState) Balance() float64 {
+	balance := 0.0
+	for _, lot := range s.Lots {
+		balance += lot.Hours
+	}
+	return balance
+}
+
+// draw consumes up to hours from the oldest lots first and returns the hours taken
+func (s *RetainerState) draw(hours float64) float64 {
+	drawn := 0.0
+	for len(s.Lots) > 0 && drawn < hours {
+		take := hours - drawn
+		if take >= s.Lots[0].Hours {
+			take = s.Lots[0].Hours
+			s.Lots = s.Lots[1:]
+		} else {
+			s.Lots[0].Hours -= take
+		}
+		drawn += take
+	}
+	return drawn
+}
+
+// forfeit drops hours from the oldest lots until at most keep hours remain
+func (s *RetainerState) forfeit(keep float64) float64 {
+	excess := s.Balance() - keep
+	if excess <= 0 {
+		return 0
+	}
+	return s.draw(excess)
+}
+
+// RetainerState returns a copy of the current retainer bank, or nil if no
+// calculation has drawn on it yet
+func (c *Calculator) RetainerState() *RetainerState {
+	if c.retainer == nil {
+		return nil
+	}
+	state := &RetainerState{Period: c.retainer.Period}
+	state.Lots = append(state.Lots, c.retainer.Lots...)
+	return state
+}
+
+// SetRetainerState restores a retainer bank saved from a previous run
+func (c *Calculator) SetRetainerState(state *RetainerState) error {
+	if state == nil {
+		c.retainer = nil
+		return nil
+	}
+	if _, err := parsePeriod(state.Period); err != nil {
+		return err
+	}
+	for _, lot := range state.Lots {
+		if _, err := parsePeriod(lot.Period); err != nil {
+			return err
+		}
+		if lot.Hours < 0 {
+			return fmt.Errorf("retainer lot hours cannot be negative: %g", lot.Hours)
+		}
+	}
+	c.retainer = &RetainerState{Period: state.Period}
+	c.retainer.Lots = append(c.retainer.Lots, state.Lots...)
+	return nil
+}
+
+// periodIndex converts a year and month into a sequential month number
+func periodIndex(year, month int) int {
+	return year*12 + month - 1
+}
+
+// parsePeriod converts a YYYY-MM string into a sequential month number
+func parsePeriod(period string) (int, error) {
+	t, err := time.Parse("2006-01", period)
+	if err != nil {
+		return 0, fmt.Errorf("invalid retainer period: %s (use YYYY-MM)", period)
+	}
+	return periodIndex(t.Year(), int(t.Month())), nil
+}
+
+// formatPeriod converts a sequential month number back into YYYY-MM
+func formatPeriod(index int) string {
+	return fmt.Sprintf("%04d-%02d", index/12, index%12+1)
+}
+
+// advanceRetainer moves the bank forward to the target period, applying
+// expiry, rollover and refill rules for every period crossed. It returns
+// the hours lost to expiry or rollover caps.
+func (c *Calculator) advanceRetainer(state *RetainerState, target int) float64 {
+	r := c.config.Retainer
+	current, _ := parsePeriod(state.Period)
+	lost := 0.0
+
+	for current < target {
+		current++
+
+		if r.ExpiryMonths > 0 {
+			kept := state.Lots[:0]
+			for _, lot := range state.Lots {
+				added, _ := parsePeriod(lot.Period)
+				if current-added >= r.ExpiryMonths {
+					lost += lot.Hours
+					continue
+				}
+				kept = append(kept, lot)
+			}
+			state.Lots = kept
+		}
+
+		if r.Refill == config.RefillMonthly {
+			if r.MaxRollover >= 0 {
+				lost += state.forfeit(r.MaxRollover)
+			}
+			state.Lots = append(state.Lots, RetainerLot{Period: formatPeriod(current), Hours: r.BankHours})
+		}
+	}
+
+	state.Period = formatPeriod(current)
+	return lost
+}
+
+// drawRetainer consumes the calculated hours from the retainer bank, month by
+// month in chronological order, and bills whatever the bank cannot cover
+func (c *Calculator) drawRetainer(result *CalculationResult) *RetainerSummary {
+	r := c.config.Retainer
+
+	type usage struct {
+		period int
+		hours  float64
+	}
+
+	var usages []usage
+	monthHours := 0.0
+	for _, monthInfo := range result.MonthDetails {
+		usages = append(usages, usage{periodIndex(monthInfo.Year, monthInfo.Month), monthInfo.Hours})
+		monthHours += monthInfo.Hours
+	}
+	sort.SliceStable(usages, func(i, j int) bool { return usages[i].period < usages[j].period })
+
+	// Hours, days, weeks and pricing adjustments are drawn in the latest billed period
+	now := c.now()
+	last := periodIndex(now.Year(), int(now.Month()))
+	if len(usages) > 0 {
+		last = usages[len(usages)-1].period
+	}
+	usages = append(usages, usage{last, result.BilledHours - monthHours})
+
+	if c.retainer == nil {
+		start := usages[0].period
+		if r.Start != "" {
+			start, _ = parsePeriod(r.Start)
+		}
+		c.retainer = &RetainerState{
+			Period: formatPeriod(start),
+			Lots:   []RetainerLot{{Period: formatPeriod(start), Hours: r.BankHours}},
+		}
+	}
+
+	summary := &RetainerSummary{OverageRate: r.OverageRate}
+	if summary.OverageRate == 0 {
+		summary.OverageRate = result.HourlyRate
+	}
+
+	for i, u := range usages {
+		summary.ExpiredHours += c.advanceRetainer(c.retainer, u.period)
+		if i == 0 {
+			summary.OpeningHours = c.retainer.Balance()
+		}
+		summary.DrawnHours += c.retainer.draw(u.hours)
+	}
+
+	summary.RemainingHours = c.retainer.Balance()
+	summary.OverageHours = result.BilledHours - summary.DrawnHours
+	summary.OverageAmount = summary.OverageHours * summary.OverageRate
+
+	return summary
+}
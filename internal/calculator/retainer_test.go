@@ -0,0 +1,155 @@
+package calculator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestCalculatorRetainerBlock(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	cfg.Retainer = &config.Retainer{BankHours: 40}
+	calc := NewCalculator(cfg)
+
+	tests := []struct {
+		name            string
+		hours           float64
+		expectedOpening float64
+		expectedDrawn   float64
+		expectedLeft    float64
+		expectedOver    float64
+	}{
+		{"first draw", 30, 40, 30, 10, 0},
+		{"exhausts bank", 15, 10, 10, 0, 5},
+		{"empty bank", 8, 0, 0, 0, 8},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := calc.Calculate(TimeInput{Hours: []float64{test.hours}}, "U$S")
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error: %v", err)
+			}
+
+			r := result.Retainer
+			if r == nil {
+				t.Fatal("Calculate() Retainer = nil, want summary")
+			}
+			if r.OpeningHours != test.expectedOpening {
+				t.Errorf("OpeningHours = %g, want %g", r.OpeningHours, test.expectedOpening)
+			}
+			if r.DrawnHours != test.expectedDrawn {
+				t.Errorf("DrawnHours = %g, want %g", r.DrawnHours, test.expectedDrawn)
+			}
+			if r.RemainingHours != test.expectedLeft {
+				t.Errorf("RemainingHours = %g, want %g", r.RemainingHours, test.expectedLeft)
+			}
+			if r.OverageHours != test.expectedOver {
+				t.Errorf("OverageHours = %g, want %g", r.OverageHours, test.expectedOver)
+			}
+			if want := test.expectedOver * cfg.HourlyRate; result.TotalAmount != want {
+				t.Errorf("TotalAmount = %.2f, want %.2f", result.TotalAmount, want)
+			}
+		})
+	}
+}
+
+func TestCalculatorRetainerMonthlyRefill(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	cfg.Retainer = &config.Retainer{
+		BankHours:   100,
+		Refill:      config.RefillMonthly,
+		Start:       "2024-01",
+		MaxRollover: 20,
+		OverageRate: 20,
+	}
+	calc := NewCalculator(cfg)
+
+	// 50h left over from January
+	if err := calc.SetRetainerState(&RetainerState{
+		Period: "2024-01",
+		Lots:   []RetainerLot{{Period: "2024-01", Hours: 50}},
+	}); err != nil {
+		t.Fatalf("SetRetainerState() unexpected error: %v", err)
+	}
+
+	// February 2024 has 29 days (232h): 50h capped to 20h rollover + 100h refill
+	result, err := calc.Calculate(TimeInput{Months: []string{"2024-02"}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	r := result.Retainer
+	if r.ExpiredHours != 30 {
+		t.Errorf("ExpiredHours = %g, want 30", r.ExpiredHours)
+	}
+	if r.OpeningHours != 120 {
+		t.Errorf("OpeningHours = %g, want 120", r.OpeningHours)
+	}
+	if r.OverageHours != 112 {
+		t.Errorf("OverageHours = %g, want 112", r.OverageHours)
+	}
+	if result.TotalAmount != 112*20 {
+		t.Errorf("TotalAmount = %.2f, want %.2f", result.TotalAmount, 112.0*20)
+	}
+	if state := calc.RetainerState(); state.Period != "2024-02" || state.Balance() != 0 {
+		t.Errorf("RetainerState() = %+v, want empty bank in 2024-02", state)
+	}
+}
+
+func TestCalculatorRetainerExpiry(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	cfg.Retainer = &config.Retainer{BankHours: 40, Start: "2024-01", ExpiryMonths: 2}
+	calc := NewCalculator(cfg)
+
+	// The January block is still valid in February but expires in March
+	result, err := calc.Calculate(TimeInput{Hours: []float64{10}, Months: []string{"2024-02"}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if result.Retainer.DrawnHours != 40 || result.Retainer.ExpiredHours != 0 {
+		t.Errorf("February retainer = %+v, want 40h drawn and nothing expired", result.Retainer)
+	}
+
+	if err := calc.SetRetainerState(&RetainerState{
+		Period: "2024-02",
+		Lots:   []RetainerLot{{Period: "2024-01", Hours: 15}},
+	}); err != nil {
+		t.Fatalf("SetRetainerState() unexpected error: %v", err)
+	}
+	result, err = calc.Calculate(TimeInput{Months: []string{"2024-03"}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if result.Retainer.ExpiredHours != 15 || result.Retainer.OpeningHours != 0 {
+		t.Errorf("March retainer = %+v, want 15h expired and empty opening", result.Retainer)
+	}
+}
+
+func TestCalculatorFormatResultRetainer(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	cfg.Retainer = &config.Retainer{BankHours: 40}
+	calc := NewCalculator(cfg)
+
+	result, err := calc.Calculate(TimeInput{Hours: []float64{50}}, "U$S")
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	output := calc.FormatResult(result)
+	expectedSubstrings := []string{
+		"Banco de horas:",
+		"Saldo inicial: 40 horas",
+		"Horas consumidas: 40 horas",
+		"Saldo restante: 0 horas",
+		"Horas excedentes: 10 × U$S 13.75 = U$S 137.50",
+		"TOTAL A FACTURAR: U$S 137.50",
+	}
+
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("FormatResult() output missing expected substring: %s", expected)
+		}
+	}
+}
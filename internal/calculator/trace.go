@@ -0,0 +1,64 @@
+package calculator
+
+import (
+	"log/slog"
+	"math"
+)
+
+// SetLogger replaces the logger that traces each step of a calculation at
+// debug level (nil means slog.Default)
+func (c *Calculator) SetLogger(logger *slog.Logger) {
+	c.logger = logger
+}
+
+// log returns the logger in use
+func (c *Calculator) log() *slog.Logger {
+	if c.logger == nil {
+		return slog.Default()
+	}
+	return c.logger
+}
+
+// trace logs a calculation step at debug level
+func (c *Calculator) trace(msg string, args ...any) {
+	c.log().Debug(msg, args...)
+}
+
+// traceRates logs the rates derived from the rate anchor of the configuration
+func (c *Calculator) traceRates() {
+	c.trace("derived rates",
+		"anchor", c.config.Anchor(),
+		"rate_amount", c.config.RateAmount,
+		"weeks_strategy", c.config.Strategy(),
+		"weekly_hours", c.config.WeeklyHours,
+		"hours_per_day", c.config.HoursPerDay,
+		"monthly_hours", c.config.MonthlyHours,
+		"hourly", c.config.HourlyRate,
+		"daily", c.config.DailyRate,
+		"weekly", c.config.WeeklyRate,
+		"monthly", c.config.MonthlySalary,
+		"annual", c.config.AnnualRate,
+	)
+}
+
+// traceMonth logs the day count of a billed month and, for February, the
+// leap-year decision behind it
+func (c *Calculator) traceMonth(monthInfo MonthInfo) {
+	args := []any{"input", monthInfo.Input, "year", monthInfo.Year, "month", monthInfo.Month, "days", monthInfo.Days}
+	if monthInfo.From != "" {
+		args = append(args, "from", monthInfo.From, "to", monthInfo.To, "day_count", "working days")
+	} else if monthInfo.Since != "" {
+		args = append(args, "since", monthInfo.Since, "until", monthInfo.Until, "day_count", "calendar days")
+	} else {
+		args = append(args, "day_count", "calendar days")
+	}
+	if monthInfo.Month == 2 {
+		args = append(args, "leap_year", IsLeapYear(monthInfo.Year))
+	}
+	c.trace("month days", args...)
+}
+
+// roundCents rounds an amount to cents, as it is displayed
+func roundCents(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
@@ -0,0 +1,60 @@
+package calculator
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestCalculateTrace(t *testing.T) {
+	var buf bytes.Buffer
+	calc := NewCalculator(config.NewBillingConfig())
+	calc.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+
+	if _, err := calc.Calculate(TimeInput{Months: []string{"2024-02", "2023-02"}, Days: []int{2}}, "U$S"); err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+
+	var steps []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var step map[string]any
+		if err := json.Unmarshal([]byte(line), &step); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		steps = append(steps, step)
+	}
+
+	leapYears := map[float64]bool{}
+	messages := map[string]bool{}
+	for _, step := range steps {
+		messages[step["msg"].(string)] = true
+		if step["msg"] == "month days" {
+			leapYears[step["year"].(float64)] = step["leap_year"].(bool)
+		}
+	}
+	for _, msg := range []string{"derived rates", "days to hours", "reference rate", "month amount", "labor amount", "total"} {
+		if !messages[msg] {
+			t.Errorf("Calculate() trace is missing the %q step", msg)
+		}
+	}
+	if !leapYears[2024] || leapYears[2023] {
+		t.Errorf("Calculate() leap years = %v, want 2024 only", leapYears)
+	}
+}
+
+func TestCalculateTraceDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	calc := NewCalculator(config.NewBillingConfig())
+	calc.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	if _, err := calc.Calculate(TimeInput{Hours: []float64{8}}, "U$S"); err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Calculate() logged %q above debug level, want nothing", buf.String())
+	}
+}
@@ -1,21 +1,174 @@
 package config
 
-import "fmt"
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
 
 // BillingConfig holds all billing configuration
 type BillingConfig struct {
-	MonthlySalary   float64
-	WeeklyHours     int
-	WorkDays        int
-	HoursPerDay     int
-	WeeksPerMonth   int
-	DefaultCurrency string
+	MonthlySalary   float64 `json:"monthly_salary"`
+	RateAnchor      string  `json:"rate_anchor,omitempty"` // rate the contract is quoted in (default monthly)
+	RateAmount      float64 `json:"rate_amount,omitempty"` // amount quoted for the anchor
+	WeeklyHours     int     `json:"weekly_hours"`
+	WorkDays        int     `json:"work_days"`
+	HoursPerDay     int     `json:"hours_per_day"`
+	WeeksPerMonth   int     `json:"weeks_per_month"`
+	WeeksStrategy   string  `json:"weeks_strategy,omitempty"` // how weeks per month are derived (default fixed)
+	DefaultCurrency string  `json:"default_currency"`
+
+	// Taxes (percentages) and expense handling
+	TaxRate        float64            `json:"tax_rate"`
+	ExpenseTaxRate float64            `json:"expense_tax_rate"`
+	ExpenseMarkup  float64            `json:"expense_markup"`
+	ExchangeRates  map[string]float64 `json:"exchange_rates,omitempty"` // keyed by "FROM/TO"
+
+	// Optional prepaid hour bank and pricing rules
+	Retainer *Retainer     `json:"retainer,omitempty"`
+	Pricing  *PricingRules `json:"pricing,omitempty"`
+
+	// Rate changes over time, in order; the rate above applies before the first
+	RateHistory []RateChange `json:"rate_history,omitempty"`
+
+	// Optional adjustment of the rate to inflation
+	Indexation *Indexation `json:"indexation,omitempty"`
+
+	// Projects of the client billed with their own rate, schedule or currency
+	Projects map[string]Project `json:"projects,omitempty"`
+
+	// People billed to the client, each with their own rate and schedule
+	Team map[string]Member `json:"team,omitempty"`
 
 	// Calculated rates
-	MonthlyHours int
-	HourlyRate   float64
-	DailyRate    float64
-	WeeklyRate   float64
+	MonthlyHours float64 `json:"-"`
+	HourlyRate   float64 `json:"-"`
+	DailyRate    float64 `json:"-"`
+	WeeklyRate   float64 `json:"-"`
+	AnnualRate   float64 `json:"-"`
+}
+
+// Retainer refill modes
+const (
+	RefillNone    = "none"
+	RefillMonthly = "monthly"
+)
+
+// Retainer describes a prepaid bank of hours that calculations draw from
+type Retainer struct {
+	BankHours    float64 `json:"bank_hours"`    // hours bought per block or per refill
+	Refill       string  `json:"refill"`        // "none" for a one-off block, "monthly" to refill every month
+	Start        string  `json:"start"`         // first period of the bank (YYYY-MM)
+	MaxRollover  float64 `json:"max_rollover"`  // unused hours carried into the next refill (-1 for all)
+	ExpiryMonths int     `json:"expiry_months"` // months after which unused hours expire (0 never)
+	OverageRate  float64 `json:"overage_rate"`  // rate for hours beyond the bank (0 uses HourlyRate)
+}
+
+// Project overrides the profile for the time billed to one project. Zero
+// values keep the profile's own settings.
+type Project struct {
+	HourlyRate  float64 `json:"hourly_rate,omitempty"`
+	HoursPerDay int     `json:"hours_per_day,omitempty"`
+	Currency    string  `json:"currency,omitempty"` // currency the project's rate is quoted in
+}
+
+// Member is a person of the team billed to the client. Zero values keep the
+// profile's own settings. The rate is set with hourly_rate, as for projects,
+// with rate_amount for the rate anchor or with monthly_salary.
+type Member struct {
+	Role          string  `json:"role,omitempty"`
+	HourlyRate    float64 `json:"hourly_rate,omitempty"`
+	RateAnchor    string  `json:"rate_anchor,omitempty"`    // default: monthly with monthly_salary, else the profile's anchor
+	RateAmount    float64 `json:"rate_amount,omitempty"`    // amount quoted for the anchor
+	MonthlySalary float64 `json:"monthly_salary,omitempty"` // monthly anchor amount
+	WeeklyHours   int     `json:"weekly_hours,omitempty"`
+	HoursPerDay   int     `json:"hours_per_day,omitempty"`
+	WorkDays      int     `json:"work_days,omitempty"`
+}
+
+// Rounding modes for billing increments
+const (
+	RoundUp      = "up"
+	RoundNearest = "nearest"
+)
+
+// PricingRules adjust the billed hours and labor amount of a profile
+type PricingRules struct {
+	Increment    float64      `json:"increment"`     // billing increment in hours (0.25 = 15 minutes)
+	Rounding     string       `json:"rounding"`      // "up" or "nearest"
+	DailyMinimum float64      `json:"daily_minimum"` // minimum hours billed per worked day
+	TotalMinimum float64      `json:"total_minimum"` // minimum hours billed per calculation
+	Discount     float64      `json:"discount"`      // percentage discount on labor
+	VolumeTiers  []VolumeTier `json:"volume_tiers,omitempty"`
+}
+
+// VolumeTier discounts every hour billed beyond a threshold
+type VolumeTier struct {
+	AboveHours float64 `json:"above_hours"`
+	Discount   float64 `json:"discount"`
+}
+
+// Validate checks if the pricing rules are valid
+func (p *PricingRules) Validate() error {
+	if p.Increment < 0 {
+		return fieldError("pricing.increment", p.Increment, "billing increment cannot be negative, got: %g", p.Increment)
+	}
+	switch p.Rounding {
+	case "", RoundUp, RoundNearest:
+	default:
+		return fieldError("pricing.rounding", p.Rounding, "invalid rounding: %s (use %s or %s)", p.Rounding, RoundUp, RoundNearest)
+	}
+	if p.DailyMinimum < 0 {
+		return fieldError("pricing.daily_minimum", p.DailyMinimum, "daily minimum cannot be negative, got: %g", p.DailyMinimum)
+	}
+	if p.TotalMinimum < 0 {
+		return fieldError("pricing.total_minimum", p.TotalMinimum, "total minimum cannot be negative, got: %g", p.TotalMinimum)
+	}
+	if p.Discount < 0 || p.Discount > 100 {
+		return fieldError("pricing.discount", p.Discount, "discount must be between 0 and 100, got: %g", p.Discount)
+	}
+	for i, tier := range p.VolumeTiers {
+		if tier.AboveHours < 0 {
+			return fieldError("pricing.volume_tiers", tier.AboveHours, "volume tier threshold cannot be negative, got: %g", tier.AboveHours)
+		}
+		if tier.Discount < 0 || tier.Discount > 100 {
+			return fieldError("pricing.volume_tiers", tier.Discount, "volume tier discount must be between 0 and 100, got: %g", tier.Discount)
+		}
+		if i > 0 && tier.AboveHours <= p.VolumeTiers[i-1].AboveHours {
+			return fieldError("pricing.volume_tiers", tier.AboveHours, "volume tiers must be in ascending order of hours")
+		}
+	}
+	return nil
+}
+
+// Validate checks if the retainer configuration is valid
+func (r *Retainer) Validate() error {
+	if r.BankHours <= 0 {
+		return fieldError("retainer.bank_hours", r.BankHours, "retainer bank hours must be positive, got: %g", r.BankHours)
+	}
+	switch r.Refill {
+	case "", RefillNone, RefillMonthly:
+	default:
+		return fieldError("retainer.refill", r.Refill, "invalid retainer refill: %s (use %s or %s)", r.Refill, RefillNone, RefillMonthly)
+	}
+	if r.Start != "" {
+		if _, err := time.Parse("2006-01", r.Start); err != nil {
+			return fieldError("retainer.start", r.Start, "invalid retainer start: %s (use YYYY-MM)", r.Start)
+		}
+	}
+	if r.MaxRollover < -1 {
+		return fieldError("retainer.max_rollover", r.MaxRollover, "retainer max rollover must be -1 or greater, got: %g", r.MaxRollover)
+	}
+	if r.ExpiryMonths < 0 {
+		return fieldError("retainer.expiry_months", r.ExpiryMonths, "retainer expiry months cannot be negative, got: %d", r.ExpiryMonths)
+	}
+	if r.OverageRate < 0 {
+		return fieldError("retainer.overage_rate", r.OverageRate, "retainer overage rate cannot be negative, got: %.2f", r.OverageRate)
+	}
+	return nil
 }
 
 // NewBillingConfig creates a new billing configuration with default values
@@ -33,19 +186,29 @@ func NewBillingConfig() *BillingConfig {
 	return config
 }
 
-// calculateRates computes all derived rates from base configuration
+// calculateRates computes all derived rates from the rate anchor, using the
+// representative month of the weeks-per-month strategy
 func (c *BillingConfig) calculateRates() {
-	c.MonthlyHours = c.WeeklyHours * c.WeeksPerMonth
-	c.HourlyRate = c.MonthlySalary / float64(c.MonthlyHours)
-	c.DailyRate = c.HourlyRate * float64(c.HoursPerDay)
-	c.WeeklyRate = c.HourlyRate * float64(c.WeeklyHours)
+	if c.WeeklyHours <= 0 || c.HoursPerDay <= 0 || c.WeeksPerMonth <= 0 {
+		return
+	}
+
+	rates := c.deriveRates(c.defaultWeeksPerMonth())
+	c.MonthlyHours = rates.MonthlyHours
+	c.HourlyRate = rates.Hourly
+	c.DailyRate = rates.Daily
+	c.WeeklyRate = rates.Weekly
+	c.MonthlySalary = rates.Monthly
+	c.AnnualRate = rates.Annual
 }
 
 // SetMonthlySalary updates the monthly salary and recalculates rates
 func (c *BillingConfig) SetMonthlySalary(salary float64) error {
 	if salary <= 0 {
-		return fmt.Errorf("monthly salary must be positive, got: %.2f", salary)
+		return fieldError("monthly_salary", salary, "monthly salary must be positive, got: %.2f", salary)
 	}
+	c.RateAnchor = AnchorMonthly
+	c.RateAmount = 0
 	c.MonthlySalary = salary
 	c.calculateRates()
 	return nil
@@ -54,7 +217,7 @@ func (c *BillingConfig) SetMonthlySalary(salary float64) error {
 // SetWeeklyHours updates weekly hours and recalculates rates
 func (c *BillingConfig) SetWeeklyHours(hours int) error {
 	if hours <= 0 {
-		return fmt.Errorf("weekly hours must be positive, got: %d", hours)
+		return fieldError("weekly_hours", hours, "weekly hours must be positive, got: %d", hours)
 	}
 	c.WeeklyHours = hours
 	c.calculateRates()
@@ -64,36 +227,317 @@ func (c *BillingConfig) SetWeeklyHours(hours int) error {
 // SetHoursPerDay updates hours per day and recalculates rates
 func (c *BillingConfig) SetHoursPerDay(hours int) error {
 	if hours <= 0 {
-		return fmt.Errorf("hours per day must be positive, got: %d", hours)
+		return fieldError("hours_per_day", hours, "hours per day must be positive, got: %d", hours)
 	}
 	c.HoursPerDay = hours
 	c.calculateRates()
 	return nil
 }
 
-// Validate checks if the configuration is valid
+// Validate checks if the configuration is valid, reporting its first problem
 func (c *BillingConfig) Validate() error {
+	if problems := c.Problems(); len(problems) > 0 {
+		return problems[0]
+	}
+	return nil
+}
+
+// Problems returns every problem of the configuration
+func (c *BillingConfig) Problems() []error {
+	var problems []error
+	if !validAnchor(c.RateAnchor) {
+		problems = append(problems, fieldError("rate_anchor", c.RateAnchor, "invalid rate anchor: %s (use hourly, daily, weekly, monthly or annual)", c.RateAnchor))
+	}
+	if c.RateAnchor != "" && c.RateAnchor != AnchorMonthly && c.RateAmount <= 0 {
+		problems = append(problems, fieldError("rate_amount", c.RateAmount, "%s rate must be positive", c.RateAnchor))
+	}
 	if c.MonthlySalary <= 0 {
-		return fmt.Errorf("monthly salary must be positive")
+		problems = append(problems, fieldError("monthly_salary", c.MonthlySalary, "monthly salary must be positive"))
 	}
 	if c.WeeklyHours <= 0 {
-		return fmt.Errorf("weekly hours must be positive")
+		problems = append(problems, fieldError("weekly_hours", c.WeeklyHours, "weekly hours must be positive"))
 	}
 	if c.HoursPerDay <= 0 {
-		return fmt.Errorf("hours per day must be positive")
+		problems = append(problems, fieldError("hours_per_day", c.HoursPerDay, "hours per day must be positive"))
 	}
-	if c.WorkDays <= 0 {
-		return fmt.Errorf("work days must be positive")
+	if c.WorkDays <= 0 || c.WorkDays > 7 {
+		problems = append(problems, fieldError("work_days", c.WorkDays, "work days must be between 1 and 7"))
 	}
 	if c.WeeksPerMonth <= 0 {
-		return fmt.Errorf("weeks per month must be positive")
+		problems = append(problems, fieldError("weeks_per_month", c.WeeksPerMonth, "weeks per month must be positive"))
+	}
+	switch c.WeeksStrategy {
+	case "", WeeksFixed, WeeksAverage, WeeksCalendar, WeeksWorking:
+	default:
+		problems = append(problems, fieldError("weeks_strategy", c.WeeksStrategy, "invalid weeks strategy: %s (use fixed, average, calendar or working)", c.WeeksStrategy))
 	}
 	if c.DefaultCurrency == "" {
-		return fmt.Errorf("default currency cannot be empty")
+		problems = append(problems, fieldError("default_currency", c.DefaultCurrency, "default currency cannot be empty"))
+	}
+	if c.TaxRate < 0 {
+		problems = append(problems, fieldError("tax_rate", c.TaxRate, "tax rate cannot be negative"))
+	}
+	if c.ExpenseTaxRate < 0 {
+		problems = append(problems, fieldError("expense_tax_rate", c.ExpenseTaxRate, "expense tax rate cannot be negative"))
+	}
+	if c.ExpenseMarkup < 0 {
+		problems = append(problems, fieldError("expense_markup", c.ExpenseMarkup, "expense markup cannot be negative"))
+	}
+	pairs := make([]string, 0, len(c.ExchangeRates))
+	for pair := range c.ExchangeRates {
+		pairs = append(pairs, pair)
+	}
+	sort.Strings(pairs)
+	for _, pair := range pairs {
+		rate := c.ExchangeRates[pair]
+		if _, _, ok := strings.Cut(pair, "/"); !ok {
+			problems = append(problems, fieldError("exchange_rates", pair, "invalid exchange rate pair: %s (use FROM/TO)", pair))
+		}
+		if rate <= 0 {
+			problems = append(problems, fieldError("exchange_rates", rate, "exchange rate %s must be positive, got: %.4f", pair, rate))
+		}
+	}
+	problems = append(problems, c.rateHistoryProblems()...)
+	names := make([]string, 0, len(c.Projects))
+	for name := range c.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		project := c.Projects[name]
+		if project.HourlyRate < 0 {
+			problems = append(problems, fieldError("projects."+name+".hourly_rate", project.HourlyRate, "project %s hourly rate cannot be negative", name))
+		}
+		if project.HoursPerDay < 0 {
+			problems = append(problems, fieldError("projects."+name+".hours_per_day", project.HoursPerDay, "project %s hours per day cannot be negative", name))
+		}
+	}
+	people := make([]string, 0, len(c.Team))
+	for name := range c.Team {
+		people = append(people, name)
+	}
+	sort.Strings(people)
+	for _, name := range people {
+		problems = append(problems, c.Team[name].problems(name)...)
+	}
+	if c.Retainer != nil {
+		if err := c.Retainer.Validate(); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if c.Pricing != nil {
+		if err := c.Pricing.Validate(); err != nil {
+			problems = append(problems, err)
+		}
 	}
+	if c.Indexation != nil {
+		if err := c.Indexation.Validate(); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	return problems
+}
+
+// ForProject returns the configuration of a project: a copy of the profile
+// with the project's overrides. The empty name returns the profile itself.
+func (c *BillingConfig) ForProject(name string) (*BillingConfig, error) {
+	if name == "" {
+		return c, nil
+	}
+	project, ok := c.Projects[name]
+	if !ok {
+		return nil, fieldError("project", name, "unknown project: %s", name)
+	}
+
+	config := *c
+	if project.HourlyRate > 0 {
+		// The project's own rate is not affected by the profile's rate changes
+		config.RateAnchor = AnchorHourly
+		config.RateAmount = project.HourlyRate
+		config.RateHistory = nil
+	}
+	if project.HoursPerDay > 0 {
+		config.HoursPerDay = project.HoursPerDay
+	}
+	if project.Currency != "" {
+		config.DefaultCurrency = project.Currency
+	}
+	config.calculateRates()
+	return &config, nil
+}
+
+// UnmarshalJSON decodes a profile, rejecting unknown keys in it and in its
+// projects, as for team members
+func (c *BillingConfig) UnmarshalJSON(data []byte) error {
+	type billingConfig BillingConfig
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	return decoder.Decode((*billingConfig)(c))
+}
+
+// UnmarshalJSON decodes a team member, rejecting unknown keys so a
+// misspelled rate is not silently ignored
+func (m *Member) UnmarshalJSON(data []byte) error {
+	type member Member
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode((*member)(m)); err != nil {
+		return fmt.Errorf("team member: %w", err)
+	}
+	return nil
+}
+
+// problems returns the problems of a team member
+func (m Member) problems(name string) []error {
+	var problems []error
+	field := "team." + name + "."
+	rates := 0
+	for _, amount := range []float64{m.HourlyRate, m.RateAmount, m.MonthlySalary} {
+		if amount > 0 {
+			rates++
+		}
+	}
+	if rates > 1 {
+		problems = append(problems, fieldError(field+"hourly_rate", m.HourlyRate, "set only one of hourly_rate, rate_amount and monthly_salary for %s", name))
+	}
+	if m.HourlyRate < 0 {
+		problems = append(problems, fieldError(field+"hourly_rate", m.HourlyRate, "hourly rate of %s cannot be negative", name))
+	}
+	if !validAnchor(m.RateAnchor) {
+		problems = append(problems, fieldError(field+"rate_anchor", m.RateAnchor, "invalid rate anchor for %s: %s", name, m.RateAnchor))
+	}
+	if m.RateAnchor != "" && m.RateAnchor != AnchorMonthly && m.RateAmount <= 0 {
+		problems = append(problems, fieldError(field+"rate_amount", m.RateAmount, "%s rate of %s must be positive", m.RateAnchor, name))
+	}
+	if m.RateAmount < 0 {
+		problems = append(problems, fieldError(field+"rate_amount", m.RateAmount, "rate of %s cannot be negative", name))
+	}
+	if m.MonthlySalary < 0 {
+		problems = append(problems, fieldError(field+"monthly_salary", m.MonthlySalary, "monthly salary of %s cannot be negative", name))
+	}
+	if m.WeeklyHours < 0 {
+		problems = append(problems, fieldError(field+"weekly_hours", m.WeeklyHours, "weekly hours of %s cannot be negative", name))
+	}
+	if m.HoursPerDay < 0 {
+		problems = append(problems, fieldError(field+"hours_per_day", m.HoursPerDay, "hours per day of %s cannot be negative", name))
+	}
+	if m.WorkDays < 0 || m.WorkDays > 7 {
+		problems = append(problems, fieldError(field+"work_days", m.WorkDays, "work days of %s must be between 1 and 7", name))
+	}
+	return problems
+}
+
+// ForPerson returns the configuration of a team member: a copy of the
+// profile with the member's rate and schedule. The empty name returns the
+// profile itself.
+func (c *BillingConfig) ForPerson(name string) (*BillingConfig, error) {
+	if name == "" {
+		return c, nil
+	}
+	member, ok := c.Team[name]
+	if !ok {
+		return nil, fieldError("person", name, "unknown team member: %s", name)
+	}
+
+	config := *c
+	switch {
+	case member.HourlyRate > 0:
+		config.RateAnchor = AnchorHourly
+		config.RateAmount = member.HourlyRate
+		config.RateHistory = nil
+	case member.RateAmount > 0:
+		config.RateAnchor = member.RateAnchor
+		if config.RateAnchor == "" {
+			config.RateAnchor = c.Anchor()
+		}
+		config.RateAmount = member.RateAmount
+		config.RateHistory = nil
+	case member.MonthlySalary > 0:
+		config.RateAnchor = AnchorMonthly
+		config.RateAmount = 0
+		config.MonthlySalary = member.MonthlySalary
+		config.RateHistory = nil
+	}
+	if member.WeeklyHours > 0 {
+		config.WeeklyHours = member.WeeklyHours
+	}
+	if member.HoursPerDay > 0 {
+		config.HoursPerDay = member.HoursPerDay
+	}
+	if member.WorkDays > 0 {
+		config.WorkDays = member.WorkDays
+	}
+	config.calculateRates()
+	return &config, nil
+}
+
+// ScaleRates multiplies the quoted rate by factor, e.g. to quote it in
+// another currency, and recalculates the derived rates
+func (c *BillingConfig) ScaleRates(factor float64) {
+	c.RateAmount *= factor
+	c.MonthlySalary *= factor
+	history := make([]RateChange, len(c.RateHistory))
+	for i, change := range c.RateHistory {
+		change.RateAmount *= factor
+		history[i] = change
+	}
+	c.RateHistory = history
+	c.calculateRates()
+}
+
+// currencyAliases maps common currency symbols to ISO codes
+var currencyAliases = map[string]string{
+	"U$S": "USD",
+	"US$": "USD",
+	"€":   "EUR",
+	"£":   "GBP",
+}
+
+// NormalizeCurrency returns the ISO code for a currency symbol or code
+func NormalizeCurrency(currency string) string {
+	code := strings.ToUpper(strings.TrimSpace(currency))
+	if alias, ok := currencyAliases[code]; ok {
+		return alias
+	}
+	return code
+}
+
+// SetExchangeRate sets the rate converting one unit of from into to
+func (c *BillingConfig) SetExchangeRate(from, to string, rate float64) error {
+	if rate <= 0 {
+		return fieldError("exchange_rates", rate, "exchange rate must be positive, got: %.4f", rate)
+	}
+	if c.ExchangeRates == nil {
+		c.ExchangeRates = map[string]float64{}
+	}
+	c.ExchangeRates[NormalizeCurrency(from)+"/"+NormalizeCurrency(to)] = rate
 	return nil
 }
 
+// ExchangeRate returns the rate converting one unit of from into to,
+// using the inverse pair when only that one is configured
+func (c *BillingConfig) ExchangeRate(from, to string) (float64, error) {
+	from, to = NormalizeCurrency(from), NormalizeCurrency(to)
+	if from == to {
+		return 1, nil
+	}
+	var inverse float64
+	for pair, rate := range c.ExchangeRates {
+		pairFrom, pairTo, _ := strings.Cut(pair, "/")
+		pairFrom, pairTo = NormalizeCurrency(pairFrom), NormalizeCurrency(pairTo)
+		if pairFrom == from && pairTo == to {
+			return rate, nil
+		}
+		if pairFrom == to && pairTo == from {
+			inverse = 1 / rate
+		}
+	}
+	if inverse > 0 {
+		return inverse, nil
+	}
+	return 0, fieldError("exchange_rates", from+"/"+to, "no exchange rate from %s to %s", from, to)
+}
+
 // String returns a formatted string representation of the configuration
 func (c *BillingConfig) String() string {
 	return fmt.Sprintf(
@@ -0,0 +1,35 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrConfig is matched with errors.Is by every configuration error
+var ErrConfig = errors.New("invalid configuration")
+
+// ErrUnknownProfile is matched with errors.Is when a profile does not exist
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// FieldError reports an invalid configuration value. It matches ErrConfig
+// and the underlying description with errors.Is.
+type FieldError struct {
+	Field string // config file field or flag holding the value
+	Value string // offending value
+	Err   error  // description of the problem
+}
+
+// Error returns the description of the problem
+func (e *FieldError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns ErrConfig and the description
+func (e *FieldError) Unwrap() []error {
+	return []error{ErrConfig, e.Err}
+}
+
+// fieldError builds a FieldError with a formatted description
+func fieldError(field string, value interface{}, format string, args ...interface{}) error {
+	return &FieldError{Field: field, Value: fmt.Sprint(value), Err: fmt.Errorf(format, args...)}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestFieldErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		run           func() error
+		expectedField string
+		expectedValue string
+	}{
+		{"rate unit", func() error { _, _, err := ParseRate("5/x"); return err }, "rate", "5/x"},
+		{"rate amount", func() error { return NewBillingConfig().SetRate(AnchorHourly, -1) }, "rate_amount", "-1"},
+		{"weeks strategy", func() error { return NewBillingConfig().SetWeeksStrategy("lunar") }, "weeks_strategy", "lunar"},
+		{"tax rate", func() error {
+			cfg := NewBillingConfig()
+			cfg.TaxRate = -21
+			return cfg.Validate()
+		}, "tax_rate", "-21"},
+		{"pricing discount", func() error {
+			cfg := NewBillingConfig()
+			cfg.Pricing = &PricingRules{Discount: 120}
+			return cfg.Validate()
+		}, "pricing.discount", "120"},
+		{"missing exchange rate", func() error { _, err := NewBillingConfig().ExchangeRate("EUR", "ARS"); return err }, "exchange_rates", "EUR/ARS"},
+		{"unknown profile", func() error { _, err := (&File{}).Profile("acme"); return err }, "profile", "acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.run()
+			if !errors.Is(err, ErrConfig) {
+				t.Fatalf("error = %v, want ErrConfig", err)
+			}
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("error = %#v, want a *FieldError", err)
+			}
+			if fieldErr.Field != tt.expectedField || fieldErr.Value != tt.expectedValue {
+				t.Errorf("field, value = %s, %s, want %s, %s", fieldErr.Field, fieldErr.Value, tt.expectedField, tt.expectedValue)
+			}
+		})
+	}
+}
+
+func TestUnknownProfile(t *testing.T) {
+	if _, err := (&File{}).Profile("acme"); !errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("Profile(acme) error = %v, want ErrUnknownProfile", err)
+	}
+
+	file := &File{Profiles: map[string]json.RawMessage{"acme": json.RawMessage(`{"tax_rate": -21}`)}}
+	if _, err := file.Profile("acme"); !errors.Is(err, ErrConfig) || errors.Is(err, ErrUnknownProfile) {
+		t.Errorf("Profile(acme) error = %v, want an invalid configuration error", err)
+	}
+}
+
+func TestProblems(t *testing.T) {
+	cfg := NewBillingConfig()
+	cfg.TaxRate = -21
+	cfg.Pricing = &PricingRules{Discount: 120}
+
+	problems := cfg.Problems()
+	if len(problems) != 2 {
+		t.Fatalf("Problems() returned %d problems, want 2: %v", len(problems), problems)
+	}
+	if err := cfg.Validate(); err == nil || err.Error() != problems[0].Error() {
+		t.Errorf("Validate() = %v, want the first problem %v", err, problems[0])
+	}
+	if problems := NewBillingConfig().Problems(); len(problems) != 0 {
+		t.Errorf("Problems() = %v, want none for the default config", problems)
+	}
+}
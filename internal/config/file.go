@@ -0,0 +1,84 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// File holds named billing profiles loaded from a JSON configuration file
+type File struct {
+	DefaultProfile string                     `json:"default_profile"`
+	Profiles       map[string]json.RawMessage `json:"profiles"`
+
+	dir string // directory of the file, which relative paths are resolved against
+}
+
+// DefaultPath returns the default location of the configuration file
+func DefaultPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "billctl", "config.json")
+}
+
+// LoadFile reads a configuration file from disk
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fieldError("file", path, "failed to read config file: %v", err)
+	}
+
+	var file File
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fieldError("file", path, "failed to parse config file %s: %v", path, err)
+	}
+	file.dir = filepath.Dir(path)
+	return &file, nil
+}
+
+// ProfileNames returns the configured profile names in alphabetical order
+func (f *File) ProfileNames() []string {
+	names := make([]string, 0, len(f.Profiles))
+	for name := range f.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Profile builds the billing configuration for a named profile.
+// Fields omitted in the profile keep their default values. An empty
+// name selects the file's default profile.
+func (f *File) Profile(name string) (*BillingConfig, error) {
+	if name == "" {
+		name = f.DefaultProfile
+	}
+	if name == "" {
+		return NewBillingConfig(), nil
+	}
+
+	raw, ok := f.Profiles[name]
+	if !ok {
+		return nil, fieldError("profile", name, "%w: %s", ErrUnknownProfile, name)
+	}
+
+	config := NewBillingConfig()
+	if err := json.Unmarshal(raw, config); err != nil {
+		return nil, fieldError("profile", name, "invalid profile %s: %v", name, err)
+	}
+	config.calculateRates()
+
+	if config.Indexation != nil {
+		if err := config.Indexation.LoadIndex(f.dir); err != nil {
+			return nil, fmt.Errorf("invalid profile %s: %w", name, err)
+		}
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid profile %s: %w", name, err)
+	}
+	return config, nil
+}
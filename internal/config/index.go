@@ -0,0 +1,228 @@
+package config
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// monthLayout is the format of indexation months
+const monthLayout = "2006-01"
+
+// Indexation adjusts the quoted rate of a profile to inflation, either by a
+// fixed percentage every period or by the ratio of an index (e.g. IPC or
+// CER) between the base month and the billed month
+type Indexation struct {
+	Name         string             `json:"name,omitempty"`          // index name for display (e.g. IPC)
+	Base         string             `json:"base"`                    // month the quoted rate is expressed in (YYYY-MM)
+	Percent      float64            `json:"percent,omitempty"`       // fixed increase per period
+	PeriodMonths int                `json:"period_months,omitempty"` // months per period of the fixed increase (default 1)
+	IndexFile    string             `json:"index_file,omitempty"`    // CSV of MONTH,VALUE rows, relative to the config file
+	Values       map[string]float64 `json:"values,omitempty"`        // index value per month (YYYY-MM)
+}
+
+// IndexAdjustment is the indexation applied to the rate of a month
+type IndexAdjustment struct {
+	Name      string  `json:"name,omitempty"`
+	Base      string  `json:"base"`              // month the rate is expressed in
+	Month     string  `json:"month"`             // month of the index value used
+	BaseValue float64 `json:"base_value"`        // index value of the base month (100 for a fixed increase)
+	Value     float64 `json:"value"`             // index value applied
+	Periods   int     `json:"periods,omitempty"` // periods of a fixed increase
+	Factor    float64 `json:"factor"`            // Value / BaseValue
+}
+
+// Validate checks if the indexation rules are valid
+func (x *Indexation) Validate() error {
+	if _, err := time.Parse(monthLayout, x.Base); err != nil {
+		return fieldError("indexation.base", x.Base, "invalid indexation base: %s (use YYYY-MM)", x.Base)
+	}
+	indexed := x.IndexFile != "" || len(x.Values) > 0
+	if x.Percent != 0 && indexed {
+		return fieldError("indexation.percent", x.Percent, "indexation takes a fixed percent or an index, not both")
+	}
+	if x.Percent == 0 && !indexed {
+		return fieldError("indexation.percent", x.Percent, "indexation needs a fixed percent or an index")
+	}
+	if x.Percent <= -100 {
+		return fieldError("indexation.percent", x.Percent, "indexation percent must be above -100, got: %g", x.Percent)
+	}
+	if x.PeriodMonths < 0 {
+		return fieldError("indexation.period_months", x.PeriodMonths, "indexation period months cannot be negative, got: %d", x.PeriodMonths)
+	}
+	if !indexed {
+		return nil
+	}
+	if len(x.Values) == 0 {
+		return fieldError("indexation.index_file", x.IndexFile, "index file %s has no values", x.IndexFile)
+	}
+	for _, month := range x.months() {
+		if _, err := time.Parse(monthLayout, month); err != nil {
+			return fieldError("indexation.values", month, "invalid index month: %s (use YYYY-MM)", month)
+		}
+		if value := x.Values[month]; value <= 0 {
+			return fieldError("indexation.values", value, "index value of %s must be positive, got: %g", month, value)
+		}
+	}
+	if _, ok := x.Values[x.Base]; !ok {
+		return fieldError("indexation.base", x.Base, "index has no value for the base month %s", x.Base)
+	}
+	return nil
+}
+
+// LoadIndex reads the index values of the index file, resolving a relative
+// path against dir
+func (x *Indexation) LoadIndex(dir string) error {
+	if x.IndexFile == "" {
+		return nil
+	}
+	path := x.IndexFile
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return fieldError("indexation.index_file", x.IndexFile, "failed to open index file: %v", err)
+	}
+	defer file.Close()
+
+	values, err := ParseIndexCSV(file)
+	if err != nil {
+		return fieldError("indexation.index_file", x.IndexFile, "%s: %v", x.IndexFile, err)
+	}
+	if x.Values == nil {
+		x.Values = map[string]float64{}
+	}
+	for month, value := range values {
+		x.Values[month] = value
+	}
+	return nil
+}
+
+// ParseIndexCSV reads index values from MONTH,VALUE rows. A header row and
+// months in YYYY-MM or YYYY-MM-DD format are accepted.
+func ParseIndexCSV(r io.Reader) (map[string]float64, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	values := map[string]float64{}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("line %d: expected MONTH,VALUE", line)
+		}
+		month, valueStr := strings.TrimSpace(record[0]), strings.TrimSpace(record[1])
+		if len(month) > len(monthLayout) {
+			month = month[:len(monthLayout)]
+		}
+		if _, err := time.Parse(monthLayout, month); err != nil {
+			if line == 1 {
+				continue // header
+			}
+			return nil, fmt.Errorf("line %d: invalid month: %s (use YYYY-MM)", line, record[0])
+		}
+		value, err := strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid index value: %s", line, valueStr)
+		}
+		values[month] = value
+	}
+	if len(values) == 0 {
+		return nil, errors.New("no index values")
+	}
+	return values, nil
+}
+
+// months returns the months of the index in chronological order
+func (x *Indexation) months() []string {
+	months := make([]string, 0, len(x.Values))
+	for month := range x.Values {
+		months = append(months, month)
+	}
+	sort.Strings(months)
+	return months
+}
+
+// valueAt returns the latest index value published for a month or before it
+func (x *Indexation) valueAt(month string) (string, float64, bool) {
+	months := x.months()
+	i := sort.SearchStrings(months, month)
+	if i < len(months) && months[i] == month {
+		return month, x.Values[month], true
+	}
+	if i == 0 {
+		return "", 0, false
+	}
+	return months[i-1], x.Values[months[i-1]], true
+}
+
+// Adjustment returns the indexation of a rate expressed in the base month
+// when billed in month, or nil for months before the base
+func (x *Indexation) Adjustment(base, month time.Time) *IndexAdjustment {
+	elapsed := (month.Year()-base.Year())*12 + int(month.Month()) - int(base.Month())
+	if elapsed < 0 {
+		return nil
+	}
+	adjustment := &IndexAdjustment{Name: x.Name, Base: base.Format(monthLayout), Month: month.Format(monthLayout)}
+
+	if x.Percent != 0 {
+		every := x.PeriodMonths
+		if every == 0 {
+			every = 1
+		}
+		adjustment.Periods = elapsed / every
+		adjustment.BaseValue = 100
+		adjustment.Factor = math.Pow(1+x.Percent/100, float64(adjustment.Periods))
+		adjustment.Value = 100 * adjustment.Factor
+		return adjustment
+	}
+
+	baseMonth, baseValue, ok := x.valueAt(adjustment.Base)
+	if !ok {
+		return nil
+	}
+	valueMonth, value, _ := x.valueAt(adjustment.Month)
+	adjustment.Base, adjustment.BaseValue = baseMonth, baseValue
+	adjustment.Month, adjustment.Value = valueMonth, value
+	adjustment.Factor = value / baseValue
+	return adjustment
+}
+
+// AsOf returns the configuration in effect on date: the rate in effect then
+// (see RateAt), indexed to the month of date. A rate change resets the base
+// of the indexation to the month it took effect. The adjustment is nil when
+// the rate is not indexed.
+func (c *BillingConfig) AsOf(date time.Time) (*BillingConfig, *IndexAdjustment) {
+	config := c.RateAt(date)
+	if c.Indexation == nil {
+		return config, nil
+	}
+
+	base, _ := time.Parse(monthLayout, c.Indexation.Base)
+	if change := c.rateChangeAt(date); change != nil && change.Since().After(base) {
+		base = change.Since()
+	}
+	adjustment := c.Indexation.Adjustment(base, date)
+	if adjustment == nil || adjustment.Factor == 1 {
+		return config, adjustment
+	}
+
+	indexed := *config
+	indexed.ScaleRates(adjustment.Factor)
+	return &indexed, adjustment
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseIndexCSV(t *testing.T) {
+	values, err := ParseIndexCSV(strings.NewReader("mes,valor\n2026-01,1000\n2026-02-01, 1030.5\n"))
+	if err != nil {
+		t.Fatalf("ParseIndexCSV() unexpected error: %v", err)
+	}
+	if len(values) != 2 || values["2026-01"] != 1000 || values["2026-02"] != 1030.5 {
+		t.Errorf("ParseIndexCSV() = %v, want 2026-01 and 2026-02", values)
+	}
+
+	for _, input := range []string{"", "mes,valor\n", "2026-01,abc\n", "2026-01,1000\nenero,1030\n", "2026-01\n"} {
+		if _, err := ParseIndexCSV(strings.NewReader(input)); err == nil {
+			t.Errorf("ParseIndexCSV(%q) expected error, got nil", input)
+		}
+	}
+}
+
+func TestIndexationAdjustment(t *testing.T) {
+	month := func(value string) time.Time {
+		date, _ := time.Parse(monthLayout, value)
+		return date
+	}
+
+	index := &Indexation{Base: "2026-01", Values: map[string]float64{"2026-01": 1000, "2026-03": 1100, "2026-06": 1250}}
+	tests := []struct {
+		month         string
+		expectedMonth string
+		expected      float64
+	}{
+		{"2026-01", "2026-01", 1},
+		{"2026-03", "2026-03", 1.1},
+		{"2026-05", "2026-03", 1.1}, // latest published value
+		{"2026-09", "2026-06", 1.25},
+	}
+	for _, test := range tests {
+		adjustment := index.Adjustment(month("2026-01"), month(test.month))
+		if adjustment == nil || adjustment.Month != test.expectedMonth || math.Abs(adjustment.Factor-test.expected) > 1e-9 {
+			t.Errorf("Adjustment(%s) = %+v, want %s value and factor %.4f", test.month, adjustment, test.expectedMonth, test.expected)
+		}
+	}
+	if adjustment := index.Adjustment(month("2026-01"), month("2025-12")); adjustment != nil {
+		t.Errorf("Adjustment(2025-12) = %+v, want nil before the base", adjustment)
+	}
+
+	escalator := &Indexation{Base: "2026-01", Percent: 10, PeriodMonths: 3}
+	adjustment := escalator.Adjustment(month("2026-01"), month("2026-08"))
+	if adjustment == nil || adjustment.Periods != 2 || math.Abs(adjustment.Factor-1.21) > 1e-9 {
+		t.Errorf("Adjustment(2026-08) = %+v, want 2 periods and factor 1.21", adjustment)
+	}
+}
+
+func TestAsOfIndexesRate(t *testing.T) {
+	cfg := NewBillingConfig()
+	if err := cfg.SetRate(AnchorHourly, 100); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.Indexation = &Indexation{Base: "2026-01", Percent: 2}
+	cfg.RateHistory = []RateChange{{EffectiveFrom: "2026-07-01", RateAmount: 150}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		date     string
+		expected float64
+	}{
+		{"2026-01-01", 100},
+		{"2026-03-01", 100 * 1.02 * 1.02},
+		{"2026-07-01", 150}, // a rate change resets the base
+		{"2026-09-01", 150 * 1.02 * 1.02},
+	}
+	for _, test := range tests {
+		date, _ := time.Parse("2006-01-02", test.date)
+		indexed, _ := cfg.AsOf(date)
+		if math.Abs(indexed.HourlyRate-test.expected) > 1e-9 {
+			t.Errorf("AsOf(%s).HourlyRate = %.4f, want %.4f", test.date, indexed.HourlyRate, test.expected)
+		}
+	}
+	if cfg.HourlyRate != 100 {
+		t.Errorf("AsOf() changed the profile rate to %.2f", cfg.HourlyRate)
+	}
+}
+
+func TestIndexationValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		indexation Indexation
+	}{
+		{"invalid base", Indexation{Base: "2026", Percent: 2}},
+		{"no rule", Indexation{Base: "2026-01"}},
+		{"both rules", Indexation{Base: "2026-01", Percent: 2, Values: map[string]float64{"2026-01": 1}}},
+		{"file not loaded", Indexation{Base: "2026-01", IndexFile: "ipc.csv"}},
+		{"base missing", Indexation{Base: "2026-01", Values: map[string]float64{"2026-02": 1}}},
+		{"negative value", Indexation{Base: "2026-01", Values: map[string]float64{"2026-01": 1, "2026-02": -1}}},
+		{"negative period", Indexation{Base: "2026-01", Percent: 2, PeriodMonths: -1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.indexation.Validate(); err == nil {
+				t.Errorf("Validate() expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestProfileLoadsIndexFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "ipc.csv"), []byte("2026-01,1000\n2026-02,1100\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := `{"profiles": {"ar": {"rate_anchor": "hourly", "rate_amount": 10,
+		"indexation": {"name": "IPC", "base": "2026-01", "index_file": "ipc.csv"}}}}`
+	path := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(path, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() unexpected error: %v", err)
+	}
+	cfg, err := file.Profile("ar")
+	if err != nil {
+		t.Fatalf("Profile(ar) unexpected error: %v", err)
+	}
+	indexed, adjustment := cfg.AsOf(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC))
+	if adjustment == nil || adjustment.Value != 1100 || math.Abs(indexed.HourlyRate-11) > 1e-9 {
+		t.Errorf("AsOf(2026-02) = %.2f with %+v, want 11.00 with IPC 1100", indexed.HourlyRate, adjustment)
+	}
+}
@@ -0,0 +1,291 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate anchors: the period a contract rate is quoted in
+const (
+	AnchorHourly  = "hourly"
+	AnchorDaily   = "daily"
+	AnchorWeekly  = "weekly"
+	AnchorMonthly = "monthly"
+	AnchorAnnual  = "annual"
+)
+
+// Weeks-per-month strategies
+const (
+	WeeksFixed    = "fixed"    // WeeksPerMonth weeks in every month
+	WeeksAverage  = "average"  // 52/12 weeks in every month
+	WeeksCalendar = "calendar" // calendar days of the billed month / 7
+	WeeksWorking  = "working"  // working days of the billed month × HoursPerDay
+)
+
+// Rates holds the rates in effect for a billing month
+type Rates struct {
+	WeeksPerMonth float64
+	MonthlyHours  float64
+	Hourly        float64
+	Daily         float64
+	Weekly        float64
+	Monthly       float64
+	Annual        float64
+}
+
+// RateChange is a contract rate that takes effect on a date, replacing the
+// rate in effect before it
+type RateChange struct {
+	EffectiveFrom string  `json:"effective_from"`        // first date billed at the rate (YYYY-MM-DD)
+	RateAnchor    string  `json:"rate_anchor,omitempty"` // default: the profile's anchor
+	RateAmount    float64 `json:"rate_amount"`
+}
+
+// dateLayout is the format of rate change dates
+const dateLayout = "2006-01-02"
+
+// Since returns the date the rate takes effect
+func (r RateChange) Since() time.Time {
+	since, _ := time.Parse(dateLayout, r.EffectiveFrom)
+	return since
+}
+
+// anchorUnits maps the accepted rate suffixes to anchors
+var anchorUnits = map[string]string{
+	"h": AnchorHourly, "hour": AnchorHourly, "hourly": AnchorHourly,
+	"d": AnchorDaily, "day": AnchorDaily, "daily": AnchorDaily,
+	"w": AnchorWeekly, "week": AnchorWeekly, "weekly": AnchorWeekly,
+	"m": AnchorMonthly, "month": AnchorMonthly, "monthly": AnchorMonthly,
+	"y": AnchorAnnual, "year": AnchorAnnual, "annual": AnchorAnnual,
+}
+
+// validAnchor reports whether anchor is a known rate anchor (empty means monthly)
+func validAnchor(anchor string) bool {
+	switch anchor {
+	case "", AnchorHourly, AnchorDaily, AnchorWeekly, AnchorMonthly, AnchorAnnual:
+		return true
+	}
+	return false
+}
+
+// ParseRate parses a rate in AMOUNT/UNIT format (e.g. 25/h, 200/d, 30000/y)
+func ParseRate(input string) (string, float64, error) {
+	amountStr, unit, ok := strings.Cut(strings.TrimSpace(input), "/")
+	if !ok {
+		return "", 0, fieldError("rate", input, "invalid rate format: %s (use AMOUNT/UNIT, e.g. 25/h)", input)
+	}
+
+	anchor, ok := anchorUnits[strings.ToLower(strings.TrimSpace(unit))]
+	if !ok {
+		return "", 0, fieldError("rate", input, "invalid rate unit: %s (use h, d, w, m or y)", unit)
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(amountStr), 64)
+	if err != nil {
+		return "", 0, fieldError("rate", input, "invalid rate amount: %s", amountStr)
+	}
+	if amount <= 0 {
+		return "", 0, fieldError("rate", input, "rate must be positive, got: %.2f", amount)
+	}
+
+	return anchor, amount, nil
+}
+
+// SetRate sets the anchor rate and recalculates all derived rates
+func (c *BillingConfig) SetRate(anchor string, amount float64) error {
+	if !validAnchor(anchor) {
+		return fieldError("rate_anchor", anchor, "invalid rate anchor: %s", anchor)
+	}
+	if amount <= 0 {
+		return fieldError("rate_amount", amount, "rate must be positive, got: %.2f", amount)
+	}
+	if anchor == "" {
+		anchor = AnchorMonthly
+	}
+	c.RateAnchor = anchor
+	c.RateAmount = amount
+	c.calculateRates()
+	return nil
+}
+
+// Anchor returns the rate anchor in effect
+func (c *BillingConfig) Anchor() string {
+	if c.RateAnchor == "" {
+		return AnchorMonthly
+	}
+	return c.RateAnchor
+}
+
+// rateHistoryProblems returns the problems of the rate changes
+func (c *BillingConfig) rateHistoryProblems() []error {
+	var problems []error
+	var previous time.Time
+	for i, change := range c.RateHistory {
+		field := fmt.Sprintf("rate_history.%d", i)
+		since, err := time.Parse(dateLayout, change.EffectiveFrom)
+		if err != nil {
+			problems = append(problems, fieldError(field+".effective_from", change.EffectiveFrom, "invalid rate change date: %s (use YYYY-MM-DD)", change.EffectiveFrom))
+			continue
+		}
+		if !previous.IsZero() && !since.After(previous) {
+			problems = append(problems, fieldError(field+".effective_from", change.EffectiveFrom, "rate changes must be in ascending order of date"))
+		}
+		previous = since
+		if !validAnchor(change.RateAnchor) {
+			problems = append(problems, fieldError(field+".rate_anchor", change.RateAnchor, "invalid rate anchor: %s", change.RateAnchor))
+		}
+		if change.RateAmount <= 0 {
+			problems = append(problems, fieldError(field+".rate_amount", change.RateAmount, "rate must be positive, got: %.2f", change.RateAmount))
+		}
+	}
+	return problems
+}
+
+// RateAt returns the configuration with the rate in effect on date: the
+// profile itself before the first rate change, or a copy quoting the latest
+// change that took effect on or before date
+func (c *BillingConfig) RateAt(date time.Time) *BillingConfig {
+	current := c.rateChangeAt(date)
+	if current == nil {
+		return c
+	}
+
+	config := *c
+	config.RateAnchor = current.RateAnchor
+	if config.RateAnchor == "" {
+		config.RateAnchor = c.Anchor()
+	}
+	config.RateAmount = current.RateAmount
+	config.calculateRates()
+	return &config
+}
+
+// rateChangeAt returns the latest rate change that took effect on or before
+// date, or nil when none did
+func (c *BillingConfig) rateChangeAt(date time.Time) *RateChange {
+	var current *RateChange
+	for i, change := range c.RateHistory {
+		if change.Since().After(date) {
+			break
+		}
+		current = &c.RateHistory[i]
+	}
+	return current
+}
+
+// RateChanges returns the rate changes taking effect after from and on or
+// before to, which split a period billed between both dates
+func (c *BillingConfig) RateChanges(from, to time.Time) []RateChange {
+	var changes []RateChange
+	for _, change := range c.RateHistory {
+		if since := change.Since(); since.After(from) && !since.After(to) {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+// SetWeeksStrategy sets how weeks per month are derived and recalculates rates
+func (c *BillingConfig) SetWeeksStrategy(strategy string) error {
+	switch strategy {
+	case WeeksFixed, WeeksAverage, WeeksCalendar, WeeksWorking:
+	default:
+		return fieldError("weeks_strategy", strategy, "invalid weeks strategy: %s (use fixed, average, calendar or working)", strategy)
+	}
+	c.WeeksStrategy = strategy
+	c.calculateRates()
+	return nil
+}
+
+// Strategy returns the weeks-per-month strategy in effect
+func (c *BillingConfig) Strategy() string {
+	if c.WeeksStrategy == "" {
+		return WeeksFixed
+	}
+	return c.WeeksStrategy
+}
+
+// MonthDependent reports whether rates vary with the billed month
+func (c *BillingConfig) MonthDependent() bool {
+	return c.WeeksStrategy == WeeksCalendar || c.WeeksStrategy == WeeksWorking
+}
+
+// IsWorkday reports whether a date falls on one of the WorkDays, counted from Monday
+func (c *BillingConfig) IsWorkday(date time.Time) bool {
+	dayIndex := (int(date.Weekday()) + 6) % 7
+	return dayIndex < c.WorkDays
+}
+
+// WorkingDays counts the workdays between two dates, both inclusive
+func (c *BillingConfig) WorkingDays(from, to time.Time) int {
+	days := 0
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		if c.IsWorkday(d) {
+			days++
+		}
+	}
+	return days
+}
+
+// defaultWeeksPerMonth returns the weeks per month used outside a specific
+// month. Month-dependent strategies fall back to the 52/12 average.
+func (c *BillingConfig) defaultWeeksPerMonth() float64 {
+	if c.Strategy() == WeeksFixed {
+		return float64(c.WeeksPerMonth)
+	}
+	return 52.0 / 12.0
+}
+
+// RatesFor returns the rates in effect for a billing month
+func (c *BillingConfig) RatesFor(year, month int) Rates {
+	first := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1)
+
+	switch c.Strategy() {
+	case WeeksCalendar:
+		return c.deriveRates(float64(last.Day()) / 7)
+	case WeeksWorking:
+		hours := float64(c.WorkingDays(first, last) * c.HoursPerDay)
+		return c.deriveRates(hours / float64(c.WeeklyHours))
+	default:
+		return c.deriveRates(c.defaultWeeksPerMonth())
+	}
+}
+
+// deriveRates computes every rate from the anchor for the given weeks per month
+func (c *BillingConfig) deriveRates(weeksPerMonth float64) Rates {
+	rates := Rates{
+		WeeksPerMonth: weeksPerMonth,
+		MonthlyHours:  float64(c.WeeklyHours) * weeksPerMonth,
+	}
+
+	switch c.RateAnchor {
+	case AnchorHourly:
+		rates.Hourly = c.RateAmount
+	case AnchorDaily:
+		rates.Hourly = c.RateAmount / float64(c.HoursPerDay)
+	case AnchorWeekly:
+		rates.Hourly = c.RateAmount / float64(c.WeeklyHours)
+	case AnchorAnnual:
+		rates.Monthly = c.RateAmount / 12
+	default:
+		rates.Monthly = c.MonthlySalary
+		if c.RateAmount > 0 {
+			rates.Monthly = c.RateAmount
+		}
+	}
+
+	switch c.RateAnchor {
+	case AnchorHourly, AnchorDaily, AnchorWeekly:
+		rates.Monthly = rates.Hourly * rates.MonthlyHours
+	default:
+		rates.Hourly = rates.Monthly / rates.MonthlyHours
+	}
+
+	rates.Daily = rates.Hourly * float64(c.HoursPerDay)
+	rates.Weekly = rates.Hourly * float64(c.WeeklyHours)
+	rates.Annual = rates.Monthly * 12
+	return rates
+}
@@ -0,0 +1,359 @@
+package config
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedAnchor string
+		expectedAmount float64
+		expectError    bool
+	}{
+		{"25/h", AnchorHourly, 25, false},
+		{"200/d", AnchorDaily, 200, false},
+		{"1000/week", AnchorWeekly, 1000, false},
+		{"2200/m", AnchorMonthly, 2200, false},
+		{"30000/Y", AnchorAnnual, 30000, false},
+		{"25", "", 0, true},
+		{"25/x", "", 0, true},
+		{"abc/h", "", 0, true},
+		{"0/h", "", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			anchor, amount, err := ParseRate(test.input)
+
+			if test.expectError {
+				if err == nil {
+					t.Errorf("ParseRate(%s) expected error, got nil", test.input)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("ParseRate(%s) unexpected error: %v", test.input, err)
+				return
+			}
+			if anchor != test.expectedAnchor || amount != test.expectedAmount {
+				t.Errorf("ParseRate(%s) = %s %.2f, want %s %.2f", test.input, anchor, amount, test.expectedAnchor, test.expectedAmount)
+			}
+		})
+	}
+}
+
+func TestSetRateDerivesAllRates(t *testing.T) {
+	tests := []struct {
+		anchor  string
+		amount  float64
+		hourly  float64
+		monthly float64
+	}{
+		{AnchorHourly, 25, 25, 4000},
+		{AnchorDaily, 200, 25, 4000},
+		{AnchorWeekly, 1000, 25, 4000},
+		{AnchorMonthly, 4000, 25, 4000},
+		{AnchorAnnual, 48000, 25, 4000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.anchor, func(t *testing.T) {
+			cfg := NewBillingConfig()
+			if err := cfg.SetRate(test.anchor, test.amount); err != nil {
+				t.Fatalf("SetRate() unexpected error: %v", err)
+			}
+
+			if cfg.HourlyRate != test.hourly {
+				t.Errorf("HourlyRate = %.2f, want %.2f", cfg.HourlyRate, test.hourly)
+			}
+			if cfg.MonthlySalary != test.monthly {
+				t.Errorf("MonthlySalary = %.2f, want %.2f", cfg.MonthlySalary, test.monthly)
+			}
+			if cfg.DailyRate != 200 || cfg.WeeklyRate != 1000 || cfg.AnnualRate != 48000 {
+				t.Errorf("derived rates = %.2f/%.2f/%.2f, want 200/1000/48000", cfg.DailyRate, cfg.WeeklyRate, cfg.AnnualRate)
+			}
+			if cfg.Anchor() != test.anchor {
+				t.Errorf("Anchor() = %s, want %s", cfg.Anchor(), test.anchor)
+			}
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("Validate() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestHourlyAnchorSurvivesScheduleChanges(t *testing.T) {
+	cfg := NewBillingConfig()
+	if err := cfg.SetRate(AnchorHourly, 25); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	if err := cfg.SetWeeklyHours(30); err != nil {
+		t.Fatalf("SetWeeklyHours() unexpected error: %v", err)
+	}
+
+	if cfg.HourlyRate != 25 {
+		t.Errorf("HourlyRate = %.2f, want 25.00", cfg.HourlyRate)
+	}
+	if cfg.MonthlySalary != 25*30*4 {
+		t.Errorf("MonthlySalary = %.2f, want %.2f", cfg.MonthlySalary, 25.0*30*4)
+	}
+
+	cfg.RateAnchor = "fortnightly"
+	if err := cfg.Validate(); err == nil {
+		t.Error("Validate() expected error for unknown anchor, got nil")
+	}
+}
+
+func TestForProject(t *testing.T) {
+	cfg := NewBillingConfig()
+	if err := cfg.SetRate(AnchorHourly, 40); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.Projects = map[string]Project{
+		"web": {HourlyRate: 50},
+		"app": {HoursPerDay: 6, Currency: "EUR"},
+	}
+
+	web, err := cfg.ForProject("web")
+	if err != nil {
+		t.Fatalf("ForProject(web) unexpected error: %v", err)
+	}
+	if web.HourlyRate != 50 || web.HoursPerDay != cfg.HoursPerDay {
+		t.Errorf("ForProject(web) = %.2f/h, %d h/day, want 50.00/h, %d h/day", web.HourlyRate, web.HoursPerDay, cfg.HoursPerDay)
+	}
+
+	app, err := cfg.ForProject("app")
+	if err != nil {
+		t.Fatalf("ForProject(app) unexpected error: %v", err)
+	}
+	if app.HourlyRate != 40 || app.HoursPerDay != 6 || app.DailyRate != 240 || app.DefaultCurrency != "EUR" {
+		t.Errorf("ForProject(app) = %.2f/h, %d h/day, %.2f/day, %s, want 40.00/h, 6 h/day, 240.00/day, EUR",
+			app.HourlyRate, app.HoursPerDay, app.DailyRate, app.DefaultCurrency)
+	}
+	if cfg.HourlyRate != 40 || cfg.HoursPerDay == 6 {
+		t.Errorf("ForProject() changed the profile to %.2f/h, %d h/day", cfg.HourlyRate, cfg.HoursPerDay)
+	}
+
+	if self, err := cfg.ForProject(""); err != nil || self != cfg {
+		t.Errorf("ForProject(\"\") = %p, %v, want the profile itself", self, err)
+	}
+	if _, err := cfg.ForProject("mobile"); err == nil {
+		t.Error("ForProject(mobile) expected error for unknown project, got nil")
+	}
+
+	web.ScaleRates(1.5)
+	if web.HourlyRate != 75 {
+		t.Errorf("ScaleRates(1.5) HourlyRate = %.2f, want 75.00", web.HourlyRate)
+	}
+}
+
+func TestForPerson(t *testing.T) {
+	cfg := NewBillingConfig()
+	if err := cfg.SetRate(AnchorHourly, 40); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.Team = map[string]Member{
+		"ana":  {Role: "Lead", RateAnchor: AnchorDaily, RateAmount: 480},
+		"luis": {MonthlySalary: 3200, WeeklyHours: 20, HoursPerDay: 4, WorkDays: 5},
+		"eva":  {Role: "QA"},
+		"mia":  {HourlyRate: 55},
+	}
+
+	ana, err := cfg.ForPerson("ana")
+	if err != nil {
+		t.Fatalf("ForPerson(ana) unexpected error: %v", err)
+	}
+	if ana.DailyRate != 480 || ana.HourlyRate != 60 {
+		t.Errorf("ForPerson(ana) = %.2f/day, %.2f/h, want 480.00/day, 60.00/h", ana.DailyRate, ana.HourlyRate)
+	}
+
+	luis, err := cfg.ForPerson("luis")
+	if err != nil {
+		t.Fatalf("ForPerson(luis) unexpected error: %v", err)
+	}
+	if luis.Anchor() != AnchorMonthly || luis.MonthlySalary != 3200 || luis.HoursPerDay != 4 || luis.WeeklyHours != 20 {
+		t.Errorf("ForPerson(luis) = %s %.2f, %d h/day, %d h/week, want monthly 3200.00, 4 h/day, 20 h/week",
+			luis.Anchor(), luis.MonthlySalary, luis.HoursPerDay, luis.WeeklyHours)
+	}
+
+	mia, err := cfg.ForPerson("mia")
+	if err != nil {
+		t.Fatalf("ForPerson(mia) unexpected error: %v", err)
+	}
+	if mia.Anchor() != AnchorHourly || mia.HourlyRate != 55 {
+		t.Errorf("ForPerson(mia) = %s %.2f/h, want hourly 55.00/h", mia.Anchor(), mia.HourlyRate)
+	}
+
+	eva, err := cfg.ForPerson("eva")
+	if err != nil {
+		t.Fatalf("ForPerson(eva) unexpected error: %v", err)
+	}
+	if eva.HourlyRate != 40 {
+		t.Errorf("ForPerson(eva) = %.2f/h, want the profile's 40.00/h", eva.HourlyRate)
+	}
+	if cfg.HourlyRate != 40 || cfg.Anchor() != AnchorHourly {
+		t.Errorf("ForPerson() changed the profile to %s %.2f/h", cfg.Anchor(), cfg.HourlyRate)
+	}
+
+	if self, err := cfg.ForPerson(""); err != nil || self != cfg {
+		t.Errorf("ForPerson(\"\") = %p, %v, want the profile itself", self, err)
+	}
+	if _, err := cfg.ForPerson("bob"); err == nil {
+		t.Error("ForPerson(bob) expected error for unknown team member, got nil")
+	}
+
+	cfg.Team["bob"] = Member{RateAnchor: AnchorDaily, WorkDays: 9}
+	cfg.Team["joe"] = Member{HourlyRate: 20, MonthlySalary: 3000}
+	if problems := cfg.Problems(); len(problems) != 3 {
+		t.Errorf("Problems() = %v, want rate amount and work days of bob and the two rates of joe", problems)
+	}
+}
+
+func TestMemberRejectsUnknownKeys(t *testing.T) {
+	var cfg BillingConfig
+	if err := json.Unmarshal([]byte(`{"team": {"ana": {"hourly_rate": 45}}}`), &cfg); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if cfg.Team["ana"].HourlyRate != 45 {
+		t.Errorf("Team[ana].HourlyRate = %.2f, want 45.00", cfg.Team["ana"].HourlyRate)
+	}
+
+	err := json.Unmarshal([]byte(`{"team": {"ana": {"hourly": 45}}}`), &cfg)
+	if err == nil || !strings.Contains(err.Error(), `unknown field "hourly"`) {
+		t.Errorf("Unmarshal() error = %v, want an unknown field error", err)
+	}
+}
+
+func TestProfileRejectsUnknownKeys(t *testing.T) {
+	for _, profile := range []string{
+		`{"hourly_rate": 45}`,
+		`{"projects": {"web": {"rate": 45}}}`,
+		`{"pricing": {"discunt": 5}}`,
+	} {
+		cfg := NewBillingConfig()
+		if err := json.Unmarshal([]byte(profile), cfg); err == nil || !strings.Contains(err.Error(), "unknown field") {
+			t.Errorf("Unmarshal(%s) error = %v, want an unknown field error", profile, err)
+		}
+	}
+
+	cfg := NewBillingConfig()
+	if err := json.Unmarshal([]byte(`{"rate_anchor": "hourly", "rate_amount": 40, "projects": {"web": {"hourly_rate": 45}}}`), cfg); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	if cfg.Projects["web"].HourlyRate != 45 || cfg.RateAmount != 40 {
+		t.Errorf("Unmarshal() = %+v, want rate 40 and web at 45", cfg)
+	}
+}
+
+func TestRateAt(t *testing.T) {
+	cfg := NewBillingConfig()
+	if err := cfg.SetRate(AnchorHourly, 40); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	cfg.RateHistory = []RateChange{
+		{EffectiveFrom: "2026-07-15", RateAmount: 50},
+		{EffectiveFrom: "2026-09-01", RateAnchor: AnchorDaily, RateAmount: 480},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		date     string
+		expected float64
+	}{
+		{"2026-07-14", 40},
+		{"2026-07-15", 50},
+		{"2026-08-31", 50},
+		{"2026-09-01", 60},
+	}
+	for _, test := range tests {
+		date, _ := time.Parse("2006-01-02", test.date)
+		if got := cfg.RateAt(date).HourlyRate; got != test.expected {
+			t.Errorf("RateAt(%s).HourlyRate = %.2f, want %.2f", test.date, got, test.expected)
+		}
+	}
+	if cfg.HourlyRate != 40 {
+		t.Errorf("RateAt() changed the profile rate to %.2f", cfg.HourlyRate)
+	}
+
+	from, _ := time.Parse("2006-01-02", "2026-07-01")
+	to, _ := time.Parse("2006-01-02", "2026-07-31")
+	if changes := cfg.RateChanges(from, to); len(changes) != 1 || changes[0].EffectiveFrom != "2026-07-15" {
+		t.Errorf("RateChanges(July) = %+v, want the 2026-07-15 change", changes)
+	}
+
+	cfg.RateHistory = []RateChange{
+		{EffectiveFrom: "2026-09-01", RateAmount: 50},
+		{EffectiveFrom: "2026-07-15", RateAmount: 0},
+		{EffectiveFrom: "15/07/2026", RateAmount: 50},
+	}
+	if problems := cfg.Problems(); len(problems) != 3 {
+		t.Errorf("Problems() = %v, want order, amount and date problems", problems)
+	}
+}
+
+func TestRatesForStrategies(t *testing.T) {
+	tests := []struct {
+		strategy      string
+		year, month   int
+		expectedWeeks float64
+		expectedHours float64
+	}{
+		{WeeksFixed, 2024, 2, 4, 160},
+		{WeeksAverage, 2024, 2, 52.0 / 12.0, 40 * 52.0 / 12.0},
+		{WeeksCalendar, 2024, 2, 29.0 / 7.0, 40 * 29.0 / 7.0},
+		{WeeksCalendar, 2023, 2, 4, 160},
+		{WeeksWorking, 2024, 2, 21.0 / 5.0, 168},  // 21 weekdays
+		{WeeksWorking, 2024, 9, 21.0 / 5.0, 168},  // 21 weekdays
+		{WeeksWorking, 2024, 10, 23.0 / 5.0, 184}, // 23 weekdays
+	}
+
+	for _, test := range tests {
+		t.Run(test.strategy, func(t *testing.T) {
+			cfg := NewBillingConfig()
+			if err := cfg.SetWeeksStrategy(test.strategy); err != nil {
+				t.Fatalf("SetWeeksStrategy() unexpected error: %v", err)
+			}
+
+			rates := cfg.RatesFor(test.year, test.month)
+			if rates.WeeksPerMonth != test.expectedWeeks {
+				t.Errorf("RatesFor(%d, %d) WeeksPerMonth = %g, want %g", test.year, test.month, rates.WeeksPerMonth, test.expectedWeeks)
+			}
+			if math.Abs(rates.MonthlyHours-test.expectedHours) > 1e-9 {
+				t.Errorf("RatesFor(%d, %d) MonthlyHours = %g, want %g", test.year, test.month, rates.MonthlyHours, test.expectedHours)
+			}
+			if want := 2200 / test.expectedHours; math.Abs(rates.Hourly-want) > 1e-9 {
+				t.Errorf("RatesFor(%d, %d) Hourly = %g, want %g", test.year, test.month, rates.Hourly, want)
+			}
+		})
+	}
+}
+
+func TestRatesForHourlyAnchorIgnoresStrategy(t *testing.T) {
+	cfg := NewBillingConfig()
+	if err := cfg.SetRate(AnchorHourly, 25); err != nil {
+		t.Fatalf("SetRate() unexpected error: %v", err)
+	}
+	if err := cfg.SetWeeksStrategy(WeeksWorking); err != nil {
+		t.Fatalf("SetWeeksStrategy() unexpected error: %v", err)
+	}
+
+	rates := cfg.RatesFor(2024, 10)
+	if rates.Hourly != 25 {
+		t.Errorf("Hourly = %.2f, want 25.00", rates.Hourly)
+	}
+	if rates.Monthly != 25*184 {
+		t.Errorf("Monthly = %.2f, want %.2f", rates.Monthly, 25.0*184)
+	}
+
+	if err := cfg.SetWeeksStrategy("lunar"); err == nil {
+		t.Error("SetWeeksStrategy() expected error for unknown strategy, got nil")
+	}
+}
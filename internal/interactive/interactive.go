@@ -0,0 +1,328 @@
+package interactive
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/pkg/billing"
+)
+
+// clearScreen moves the cursor home and clears the terminal
+const clearScreen = "\033[H\033[2J"
+
+// Session is a prompt-driven calculation. Every answer recalculates the
+// result and redraws the breakdown.
+type Session struct {
+	In       io.Reader
+	Out      io.Writer
+	Profiles []string                                         // profile names offered at the client prompt
+	Profile  func(name string) (*config.BillingConfig, error) // resolves the chosen profile
+	Clock    billing.Clock
+	Currency string                                                                       // empty uses the profile's default currency
+	Save     func(path string, data []byte) error                                         // writes exported files
+	Clear    bool                                                                         // clear the screen before every redraw
+	Check    func(client string, cfg *config.BillingConfig, result *billing.Result) error // blocks exports that break the billing policy
+
+	scanner *bufio.Scanner
+	client  string
+	config  *config.BillingConfig
+	calc    *billing.Calculator
+	input   billing.Input
+	result  *billing.Result
+}
+
+// Script returns a reader that answers the prompts of a session with the
+// given lines, one per prompt
+func Script(lines ...string) io.Reader {
+	return strings.NewReader(strings.Join(lines, "\n") + "\n")
+}
+
+// Run prompts for a calculation and then offers to export it until the user
+// quits or the input ends
+func (s *Session) Run() error {
+	s.scanner = bufio.NewScanner(s.In)
+	fmt.Fprintln(s.Out, "=== BILLCTL - MODO INTERACTIVO ===")
+
+	for {
+		if err := s.collect(); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+
+		restart, err := s.menu()
+		if err == io.EOF || (err == nil && !restart) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// collect prompts for the client, periods, days and hours
+func (s *Session) collect() error {
+	s.input = billing.Input{Currency: s.Currency}
+	s.result = nil
+
+	prompt := "Cliente"
+	if len(s.Profiles) > 0 {
+		prompt = fmt.Sprintf("Cliente (%s)", strings.Join(s.Profiles, ", "))
+	}
+	if err := s.ask(prompt, func(answer string) error {
+		cfg, err := s.Profile(answer)
+		if err != nil {
+			return err
+		}
+		opts := []billing.Option{billing.WithConfig(cfg)}
+		if s.Clock != nil {
+			opts = append(opts, billing.WithClock(s.Clock))
+		}
+		calc, err := billing.New(opts...)
+		if err != nil {
+			return err
+		}
+		s.client, s.config, s.calc = answer, cfg, calc
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if err := s.ask("Períodos (ej. 2026-09, 2026-Q3, last-month; vacío para ninguno)", func(answer string) error {
+		months := strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' })
+		return s.update(func(input *billing.Input) { input.Months = months })
+	}); err != nil {
+		return err
+	}
+
+	if err := s.ask("Días adicionales", func(answer string) error {
+		if answer == "" {
+			return nil
+		}
+		days, err := strconv.Atoi(answer)
+		if err != nil {
+			return fmt.Errorf("número de días inválido: %s", answer)
+		}
+		return s.update(func(input *billing.Input) { input.Days = []int{days} })
+	}); err != nil {
+		return err
+	}
+
+	return s.ask("Horas adicionales", func(answer string) error {
+		if answer == "" {
+			return nil
+		}
+		hours, err := strconv.ParseFloat(strings.Replace(answer, ",", ".", 1), 64)
+		if err != nil {
+			return fmt.Errorf("número de horas inválido: %s", answer)
+		}
+		return s.update(func(input *billing.Input) { input.Hours = []float64{hours} })
+	})
+}
+
+// update applies a change to the input and recalculates, keeping the
+// previous input when the calculation fails
+func (s *Session) update(change func(input *billing.Input)) error {
+	input := s.input
+	change(&input)
+
+	if len(input.Months) == 0 && len(input.Days) == 0 && len(input.Hours) == 0 {
+		s.input, s.result = input, nil
+		return nil
+	}
+
+	result, err := s.calc.Calculate(context.Background(), input)
+	if err != nil {
+		return err
+	}
+	s.input, s.result = input, result
+	s.redraw()
+	return nil
+}
+
+// redraw shows the current breakdown
+func (s *Session) redraw() {
+	if s.Clear {
+		fmt.Fprint(s.Out, clearScreen)
+	}
+	fmt.Fprintln(s.Out)
+	fmt.Fprint(s.Out, s.breakdown())
+}
+
+// breakdown renders the current result as text
+func (s *Session) breakdown() string {
+	output, _ := s.calc.Format(s.result, billing.FormatText) // only unknown formats fail
+	return output
+}
+
+// menu offers the actions on a finished calculation. It reports whether
+// the user wants to start a new calculation.
+func (s *Session) menu() (bool, error) {
+	if s.result == nil {
+		fmt.Fprintln(s.Out, "\nNo se ingresó tiempo trabajado.")
+	}
+
+	for {
+		answer, err := s.read("\n[e] Exportar  [f] Guardar factura  [n] Nuevo cálculo  [q] Salir")
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "e":
+			if err := s.export(); err != nil {
+				return false, err
+			}
+		case "f":
+			if err := s.invoice(); err != nil {
+				return false, err
+			}
+		case "n":
+			return true, nil
+		case "q", "":
+			return false, nil
+		default:
+			fmt.Fprintf(s.Out, "Opción inválida: %s\n", answer)
+		}
+	}
+}
+
+// export writes the result to a file in text, JSON or CSV format
+func (s *Session) export() error {
+	if s.result == nil {
+		fmt.Fprintln(s.Out, "No hay resultado para exportar.")
+		return nil
+	}
+	if !s.allowed() {
+		return nil
+	}
+
+	format := billing.FormatJSON
+	if err := s.ask("Formato (text, json, csv) [json]", func(answer string) error {
+		if answer != "" {
+			format = strings.ToLower(answer)
+		}
+		return calculator.ValidateOutput(format)
+	}); err != nil {
+		return err
+	}
+
+	output, err := s.calc.Format(s.result, format)
+	if err != nil {
+		return err
+	}
+	return s.saveAs("resultado."+extension(format), []byte(output))
+}
+
+// invoice saves the breakdown as a numbered invoice
+func (s *Session) invoice() error {
+	if s.result == nil {
+		fmt.Fprintln(s.Out, "No hay resultado para facturar.")
+		return nil
+	}
+	if !s.allowed() {
+		return nil
+	}
+
+	number, err := s.read("Número de factura")
+	if err != nil {
+		return err
+	}
+	if number == "" {
+		number = s.now().Format("20060102")
+	}
+
+	var invoice strings.Builder
+	invoice.WriteString(fmt.Sprintf("FACTURA N° %s\n", number))
+	invoice.WriteString(fmt.Sprintf("Fecha: %s\n", s.now().Format("2006-01-02")))
+	if s.client != "" {
+		invoice.WriteString(fmt.Sprintf("Cliente: %s\n", s.client))
+	}
+	invoice.WriteString("\n")
+	invoice.WriteString(s.breakdown())
+
+	return s.saveAs("factura-"+number+".txt", []byte(invoice.String()))
+}
+
+// allowed reports whether the result respects the billing policy, showing
+// the violations when it does not
+func (s *Session) allowed() bool {
+	if s.Check == nil {
+		return true
+	}
+	if err := s.Check(s.client, s.config, s.result); err != nil {
+		fmt.Fprintf(s.Out, "Error: %v\n", err)
+		return false
+	}
+	return true
+}
+
+// saveAs prompts for a file name and writes data to it
+func (s *Session) saveAs(defaultPath string, data []byte) error {
+	path, err := s.read(fmt.Sprintf("Archivo [%s]", defaultPath))
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		path = defaultPath
+	}
+
+	if err := s.Save(path, data); err != nil {
+		fmt.Fprintf(s.Out, "Error: %v\n", err)
+		return nil
+	}
+	fmt.Fprintf(s.Out, "Guardado en %s\n", path)
+	return nil
+}
+
+// ask prompts until apply accepts the answer
+func (s *Session) ask(prompt string, apply func(answer string) error) error {
+	for {
+		answer, err := s.read(prompt)
+		if err != nil {
+			return err
+		}
+		if err := apply(answer); err != nil {
+			fmt.Fprintf(s.Out, "Error: %v\n", err)
+			continue
+		}
+		return nil
+	}
+}
+
+// read shows a prompt and returns the trimmed answer
+func (s *Session) read(prompt string) (string, error) {
+	fmt.Fprintf(s.Out, "%s: ", prompt)
+	if !s.scanner.Scan() {
+		fmt.Fprintln(s.Out)
+		if err := s.scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", io.EOF
+	}
+	return strings.TrimSpace(s.scanner.Text()), nil
+}
+
+// now returns the current date of the session clock
+func (s *Session) now() time.Time {
+	if s.Clock == nil {
+		return time.Now()
+	}
+	return s.Clock.Now()
+}
+
+// extension returns the file extension of an output format
+func extension(format string) string {
+	if format == billing.FormatText {
+		return "txt"
+	}
+	return format
+}
@@ -0,0 +1,132 @@
+package interactive
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// newTestSession returns a session driven by a script that records saved files
+func newTestSession(saved map[string]string, lines ...string) (*Session, *bytes.Buffer) {
+	var out bytes.Buffer
+	return &Session{
+		In:       Script(lines...),
+		Out:      &out,
+		Profiles: []string{"acme"},
+		Profile: func(name string) (*config.BillingConfig, error) {
+			cfg := config.NewBillingConfig()
+			switch name {
+			case "":
+			case "acme":
+				cfg.SetRate(config.AnchorHourly, 25)
+				cfg.DefaultCurrency = "EUR"
+			default:
+				return nil, fmt.Errorf("unknown profile: %s", name)
+			}
+			return cfg, nil
+		},
+		Clock: calculator.FixedClock{Time: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		Save: func(path string, data []byte) error {
+			saved[path] = string(data)
+			return nil
+		},
+	}, &out
+}
+
+func TestSessionRun(t *testing.T) {
+	saved := map[string]string{}
+	session, out := newTestSession(saved,
+		"acme",          // client
+		"last-month",    // periods
+		"2",             // extra days
+		"1,5",           // extra hours
+		"e", "json", "", // export as JSON to the default file
+		"f", "A-1", "", // invoice
+		"q",
+	)
+
+	if err := session.Run(); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	output := out.String()
+	expectedSubstrings := []string{
+		"Cliente (acme):",
+		"Meses: 2026-09 (30 días)",
+		"Días: 2 × 8 horas = 16 horas",
+		"Horas adicionales: 1.5 horas",
+		"TOTAL A FACTURAR: EUR 6437.50",
+		"Guardado en resultado.json",
+		"Guardado en factura-A-1.txt",
+	}
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Run() output missing expected substring: %s", expected)
+		}
+	}
+
+	// The breakdown is redrawn after every answer that changes the input
+	if count := strings.Count(output, "=== CÁLCULO DE FACTURACIÓN ==="); count != 3 {
+		t.Errorf("Run() redrew the breakdown %d times, want 3", count)
+	}
+
+	var exported calculator.CalculationResult
+	if err := json.Unmarshal([]byte(saved["resultado.json"]), &exported); err != nil {
+		t.Fatalf("exported JSON is invalid: %v", err)
+	}
+	if exported.TotalAmount != 6437.5 || exported.Currency != "EUR" {
+		t.Errorf("exported result = %s %.2f, want EUR 6437.50", exported.Currency, exported.TotalAmount)
+	}
+
+	invoice := saved["factura-A-1.txt"]
+	for _, expected := range []string{"FACTURA N° A-1", "Fecha: 2026-10-18", "Cliente: acme", "TOTAL A FACTURAR: EUR 6437.50"} {
+		if !strings.Contains(invoice, expected) {
+			t.Errorf("invoice missing expected substring: %s", expected)
+		}
+	}
+}
+
+func TestSessionRepromptsInvalidAnswers(t *testing.T) {
+	saved := map[string]string{}
+	session, out := newTestSession(saved,
+		"nobody", "", // unknown profile, then the default one
+		"2026-13", "2026-Q1", // invalid period, then a quarter
+		"many", "", // invalid days, then none
+		"", // no hours
+		"x", "e", "xml", "csv", "out.csv",
+		"n",            // start over
+		"", "", "", "", // nothing entered
+		"e", // nothing to export
+	)
+
+	if err := session.Run(); err != nil {
+		t.Fatalf("Run() unexpected error: %v", err)
+	}
+
+	output := out.String()
+	expectedSubstrings := []string{
+		"Error: unknown profile: nobody",
+		"Error: invalid month '2026-13'",
+		"Error: número de días inválido: many",
+		"Opción inválida: x",
+		"Error: invalid output format: xml",
+		"Guardado en out.csv",
+		"No se ingresó tiempo trabajado.",
+		"No hay resultado para exportar.",
+	}
+	for _, expected := range expectedSubstrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Run() output missing expected substring: %s", expected)
+		}
+	}
+
+	if !strings.Contains(saved["out.csv"], "summary,total,,,9900.00") {
+		t.Errorf("out.csv = %q, want the 2026-Q1 total", saved["out.csv"])
+	}
+}
@@ -0,0 +1,252 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/pkg/billing"
+)
+
+// AllClients is the key of the required fields that apply to every client
+const AllClients = "*"
+
+// Policy holds the guardrails every calculation must respect
+type Policy struct {
+	MinHourlyRate     float64             `json:"min_hourly_rate"`        // floor for every billed hourly rate (0 disables)
+	MaxHourlyRate     float64             `json:"max_hourly_rate"`        // ceiling for every billed hourly rate (0 disables)
+	AllowedCurrencies []string            `json:"allowed_currencies"`     // empty allows any currency
+	RequiredFields    map[string][]string `json:"required_fields"`        // profile fields that must be set, per client or "*"
+	MaxDiscount       float64             `json:"max_discount"`           // highest percentage discount allowed (0 disables)
+	OverrideLog       string              `json:"override_log,omitempty"` // JSON lines file recording overrides
+}
+
+// Violation is a policy rule broken by a calculation
+type Violation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ViolationError reports the violations that block a calculation
+type ViolationError struct {
+	Client     string
+	Violations []Violation
+}
+
+// Error lists every violation
+func (e *ViolationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return fmt.Sprintf("policy violations: %s", strings.Join(messages, "; "))
+}
+
+// Load reads a policy file. A relative override log is resolved against
+// the policy file's directory.
+func Load(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %v", err)
+	}
+
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy file %s: %v", path, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s: %v", path, err)
+	}
+
+	if p.OverrideLog == "" {
+		p.OverrideLog = "policy-overrides.jsonl"
+	}
+	if !filepath.IsAbs(p.OverrideLog) {
+		p.OverrideLog = filepath.Join(filepath.Dir(path), p.OverrideLog)
+	}
+	return &p, nil
+}
+
+// Validate checks if the policy is consistent
+func (p *Policy) Validate() error {
+	if p.MinHourlyRate < 0 || p.MaxHourlyRate < 0 {
+		return fmt.Errorf("hourly rate limits cannot be negative")
+	}
+	if p.MaxHourlyRate > 0 && p.MinHourlyRate > p.MaxHourlyRate {
+		return fmt.Errorf("min hourly rate %.2f is above max hourly rate %.2f", p.MinHourlyRate, p.MaxHourlyRate)
+	}
+	if p.MaxDiscount < 0 || p.MaxDiscount > 100 {
+		return fmt.Errorf("max discount must be between 0 and 100, got: %g", p.MaxDiscount)
+	}
+	return nil
+}
+
+// Evaluate checks a profile and its calculation result against the policy
+func (p *Policy) Evaluate(client string, cfg *config.BillingConfig, result *billing.Result) []Violation {
+	var violations []Violation
+	add := func(rule, format string, args ...interface{}) {
+		violations = append(violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if result != nil {
+		rates := map[string]float64{"hourly rate": result.HourlyRate}
+		for _, monthInfo := range result.Months {
+			label := "hourly rate of " + monthInfo.Input
+			if monthInfo.Since != "" {
+				label += " from " + monthInfo.Since
+			}
+			rates[label] = monthInfo.HourlyRate
+		}
+		for _, project := range result.Projects {
+			if project.Project != "" && !project.MixedRates {
+				rates["hourly rate of project "+project.Project] = project.HourlyRate
+			}
+		}
+		for _, person := range result.People {
+			if person.Person != "" && !person.MixedRates {
+				rates["hourly rate of team member "+person.Person] = person.HourlyRate
+			}
+		}
+		for _, part := range result.Parts {
+			if part.Project != "" && part.Person != "" && !part.MixedRates {
+				rates["hourly rate of team member "+part.Person+" on project "+part.Project] = part.HourlyRate
+			}
+		}
+		if result.Retainer != nil && result.Retainer.OverageHours > 0 {
+			rates["overage rate"] = result.Retainer.OverageRate
+		}
+		for _, label := range sortedKeys(rates) {
+			rate := rates[label]
+			if p.MinHourlyRate > 0 && rate < p.MinHourlyRate {
+				add("min_hourly_rate", "%s %.2f is below the minimum of %.2f", label, rate, p.MinHourlyRate)
+			}
+			if p.MaxHourlyRate > 0 && rate > p.MaxHourlyRate {
+				add("max_hourly_rate", "%s %.2f is above the maximum of %.2f", label, rate, p.MaxHourlyRate)
+			}
+		}
+
+		if len(p.AllowedCurrencies) > 0 && !p.allowsCurrency(result.Currency) {
+			add("allowed_currencies", "currency %s is not allowed (allowed: %s)",
+				result.Currency, strings.Join(p.AllowedCurrencies, ", "))
+		}
+	}
+
+	if p.MaxDiscount > 0 && cfg.Pricing != nil {
+		if cfg.Pricing.Discount > p.MaxDiscount {
+			add("max_discount", "discount of %g%% exceeds the maximum of %g%%", cfg.Pricing.Discount, p.MaxDiscount)
+		}
+		for _, tier := range cfg.Pricing.VolumeTiers {
+			if tier.Discount > p.MaxDiscount {
+				add("max_discount", "volume discount of %g%% exceeds the maximum of %g%%", tier.Discount, p.MaxDiscount)
+			}
+		}
+	}
+
+	for _, field := range p.requiredFields(client) {
+		set, known := fieldSet(cfg, field)
+		if !known {
+			add("required_fields", "unknown required field: %s", field)
+		} else if !set {
+			add("required_fields", "required field %s is not set", field)
+		}
+	}
+
+	return violations
+}
+
+// Check evaluates the policy and returns a ViolationError when it is broken
+func (p *Policy) Check(client string, cfg *config.BillingConfig, result *billing.Result) error {
+	if violations := p.Evaluate(client, cfg, result); len(violations) > 0 {
+		return &ViolationError{Client: client, Violations: violations}
+	}
+	return nil
+}
+
+// Override is a recorded decision to bill despite policy violations
+type Override struct {
+	Time       time.Time   `json:"time"`
+	User       string      `json:"user,omitempty"`
+	Client     string      `json:"client,omitempty"`
+	Reason     string      `json:"reason"`
+	Violations []Violation `json:"violations"`
+	Total      float64     `json:"total"`
+	Currency   string      `json:"currency"`
+}
+
+// RecordOverride appends an override to the policy's override log
+func (p *Policy) RecordOverride(override Override) error {
+	if strings.TrimSpace(override.Reason) == "" {
+		return fmt.Errorf("overriding the policy requires a reason")
+	}
+
+	data, err := json.Marshal(override)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(p.OverrideLog, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open override log: %v", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to record override: %v", err)
+	}
+	return nil
+}
+
+// allowsCurrency reports whether a currency is in the allowed list
+func (p *Policy) allowsCurrency(currency string) bool {
+	code := config.NormalizeCurrency(currency)
+	for _, allowed := range p.AllowedCurrencies {
+		if config.NormalizeCurrency(allowed) == code {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredFields returns the fields required for every client plus those
+// required for this one
+func (p *Policy) requiredFields(client string) []string {
+	fields := append([]string{}, p.RequiredFields[AllClients]...)
+	if client != "" && client != AllClients {
+		fields = append(fields, p.RequiredFields[client]...)
+	}
+	return fields
+}
+
+// fieldSet reports whether a profile field, named as in the config file,
+// has a non-zero value, and whether the field exists at all
+func fieldSet(cfg *config.BillingConfig, field string) (set, known bool) {
+	value := reflect.ValueOf(cfg).Elem()
+	for i := 0; i < value.NumField(); i++ {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || name != field {
+			continue
+		}
+		f := value.Field(i)
+		if f.Kind() == reflect.Map || f.Kind() == reflect.Slice {
+			return f.Len() > 0, true
+		}
+		return !f.IsZero(), true
+	}
+	return false, false
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys(m map[string]float64) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
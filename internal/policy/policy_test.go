@@ -0,0 +1,234 @@
+package policy
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/pkg/billing"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy Policy
+		client string
+		setup  func(cfg *config.BillingConfig)
+		result billing.Result
+		rules  []string
+	}{
+		{
+			name:   "within limits",
+			policy: Policy{MinHourlyRate: 10, MaxHourlyRate: 50, AllowedCurrencies: []string{"USD"}},
+			result: billing.Result{HourlyRate: 25, Currency: "U$S"},
+		},
+		{
+			name:   "rate below minimum",
+			policy: Policy{MinHourlyRate: 30},
+			result: billing.Result{HourlyRate: 25, Currency: "USD"},
+			rules:  []string{"min_hourly_rate"},
+		},
+		{
+			name:   "month rate above maximum",
+			policy: Policy{MaxHourlyRate: 30},
+			result: billing.Result{
+				HourlyRate: 25,
+				Currency:   "USD",
+				Months:     []billing.Month{{Input: "2026-09", HourlyRate: 35}},
+			},
+			rules: []string{"max_hourly_rate"},
+		},
+		{
+			name:   "project rate below minimum",
+			policy: Policy{MinHourlyRate: 18},
+			result: billing.Result{
+				HourlyRate: 25,
+				Currency:   "USD",
+				Projects:   []billing.ProjectResult{{Project: "web", HourlyRate: 30}, {Project: "maint", HourlyRate: 15}},
+			},
+			rules: []string{"min_hourly_rate"},
+		},
+		{
+			name:   "team member rate below minimum",
+			policy: Policy{MinHourlyRate: 18},
+			result: billing.Result{
+				HourlyRate: 25,
+				Currency:   "USD",
+				People:     []billing.PersonResult{{Person: "ana", HourlyRate: 60}, {Person: "luis", HourlyRate: 9}},
+			},
+			rules: []string{"min_hourly_rate"},
+		},
+		{
+			name:   "team member rate below minimum on one project",
+			policy: Policy{MinHourlyRate: 18},
+			result: billing.Result{
+				HourlyRate: 25,
+				Currency:   "USD",
+				People:     []billing.PersonResult{{Person: "ana", MixedRates: true}},
+				Parts: []billing.PartResult{
+					{Project: "web", Person: "ana", HourlyRate: 30},
+					{Project: "maint", Person: "ana", HourlyRate: 15},
+				},
+			},
+			rules: []string{"min_hourly_rate"},
+		},
+		{
+			name:   "currency not allowed",
+			policy: Policy{AllowedCurrencies: []string{"USD", "EUR"}},
+			result: billing.Result{HourlyRate: 25, Currency: "ARS"},
+			rules:  []string{"allowed_currencies"},
+		},
+		{
+			name:   "discount above maximum",
+			policy: Policy{MaxDiscount: 10},
+			setup: func(cfg *config.BillingConfig) {
+				cfg.Pricing = &config.PricingRules{
+					Discount:    15,
+					VolumeTiers: []config.VolumeTier{{AboveHours: 100, Discount: 5}, {AboveHours: 200, Discount: 20}},
+				}
+			},
+			result: billing.Result{HourlyRate: 25, Currency: "USD"},
+			rules:  []string{"max_discount", "max_discount"},
+		},
+		{
+			name:   "required fields for every client",
+			policy: Policy{RequiredFields: map[string][]string{AllClients: {"tax_rate", "exchange_rates"}}},
+			result: billing.Result{HourlyRate: 25, Currency: "USD"},
+			rules:  []string{"required_fields", "required_fields"},
+		},
+		{
+			name:   "required fields per client",
+			policy: Policy{RequiredFields: map[string][]string{"acme": {"retainer"}, "beta": {"pricing"}}},
+			client: "acme",
+			setup: func(cfg *config.BillingConfig) {
+				cfg.Pricing = &config.PricingRules{}
+			},
+			result: billing.Result{HourlyRate: 25, Currency: "USD"},
+			rules:  []string{"required_fields"},
+		},
+		{
+			name:   "unknown required field",
+			policy: Policy{RequiredFields: map[string][]string{AllClients: {"vat_number"}}},
+			result: billing.Result{HourlyRate: 25, Currency: "USD"},
+			rules:  []string{"required_fields"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewBillingConfig()
+			if tt.setup != nil {
+				tt.setup(cfg)
+			}
+
+			violations := tt.policy.Evaluate(tt.client, cfg, &tt.result)
+			if len(violations) != len(tt.rules) {
+				t.Fatalf("Evaluate() returned %d violations %v, want %d", len(violations), violations, len(tt.rules))
+			}
+			for i, violation := range violations {
+				if violation.Rule != tt.rules[i] {
+					t.Errorf("Evaluate()[%d].Rule = %s, want %s", i, violation.Rule, tt.rules[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	p := Policy{MinHourlyRate: 30}
+	result := &billing.Result{HourlyRate: 25, Currency: "USD"}
+
+	err := p.Check("acme", config.NewBillingConfig(), result)
+	var violationErr *ViolationError
+	if !errors.As(err, &violationErr) {
+		t.Fatalf("Check() error = %v, want a *ViolationError", err)
+	}
+	if violationErr.Client != "acme" || len(violationErr.Violations) != 1 {
+		t.Errorf("Check() error = %+v, want one violation for acme", violationErr)
+	}
+	if !strings.Contains(err.Error(), "below the minimum of 30.00") {
+		t.Errorf("Check() error = %q, want the minimum rate", err.Error())
+	}
+
+	result.HourlyRate = 30
+	if err := p.Check("acme", config.NewBillingConfig(), result); err != nil {
+		t.Errorf("Check() unexpected error: %v", err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "policy.json")
+	data := `{"min_hourly_rate": 10, "allowed_currencies": ["USD"], "required_fields": {"*": ["tax_rate"]}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+	if p.MinHourlyRate != 10 || len(p.AllowedCurrencies) != 1 || len(p.RequiredFields[AllClients]) != 1 {
+		t.Errorf("Load() = %+v, want the policy from the file", p)
+	}
+	if want := filepath.Join(dir, "policy-overrides.jsonl"); p.OverrideLog != want {
+		t.Errorf("Load() OverrideLog = %s, want %s", p.OverrideLog, want)
+	}
+
+	invalid := filepath.Join(dir, "invalid.json")
+	if err := os.WriteFile(invalid, []byte(`{"min_hourly_rate": 50, "max_hourly_rate": 20}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(invalid); err == nil {
+		t.Error("Load() expected an error for min above max, got nil")
+	}
+}
+
+func TestRecordOverride(t *testing.T) {
+	p := Policy{OverrideLog: filepath.Join(t.TempDir(), "overrides.jsonl")}
+	override := Override{
+		Time:       time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC),
+		Client:     "acme",
+		Reason:     "rate agreed before the policy",
+		Violations: []Violation{{Rule: "min_hourly_rate", Message: "hourly rate 25.00 is below the minimum of 30.00"}},
+		Total:      1000,
+		Currency:   "USD",
+	}
+
+	if err := p.RecordOverride(Override{Client: "acme"}); err == nil {
+		t.Error("RecordOverride() expected an error without a reason, got nil")
+	}
+	for i := 0; i < 2; i++ {
+		if err := p.RecordOverride(override); err != nil {
+			t.Fatalf("RecordOverride() unexpected error: %v", err)
+		}
+	}
+
+	f, err := os.Open(p.OverrideLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var lines int
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var recorded Override
+		if err := json.Unmarshal(scanner.Bytes(), &recorded); err != nil {
+			t.Fatalf("override log line %d is not JSON: %v", lines+1, err)
+		}
+		if recorded.Reason != override.Reason || len(recorded.Violations) != 1 {
+			t.Errorf("override log line %d = %+v, want %+v", lines+1, recorded, override)
+		}
+		lines++
+	}
+	if lines != 2 {
+		t.Errorf("override log has %d lines, want 2", lines)
+	}
+}
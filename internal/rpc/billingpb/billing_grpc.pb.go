@@ -0,0 +1,246 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.27.1
+// source: billctl/v1/billing.proto
+
+// Billing calculations over gRPC, matching the billctl CLI and HTTP API.
+
+package billingpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BillingService_Calculate_FullMethodName       = "/billctl.v1.BillingService/Calculate"
+	BillingService_GetRates_FullMethodName        = "/billctl.v1.BillingService/GetRates"
+	BillingService_GetMonthSummary_FullMethodName = "/billctl.v1.BillingService/GetMonthSummary"
+	BillingService_CalculateBatch_FullMethodName  = "/billctl.v1.BillingService/CalculateBatch"
+)
+
+// BillingServiceClient is the client API for BillingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BillingService exposes the calculator
+type BillingServiceClient interface {
+	// Calculate a billing amount
+	Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculationResult, error)
+	// Rate table of a profile
+	GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error)
+	// Hours and amount of a whole month
+	GetMonthSummary(ctx context.Context, in *GetMonthSummaryRequest, opts ...grpc.CallOption) (*MonthSummary, error)
+	// Calculate a stream of jobs. Every request gets a result in order;
+	// failing jobs carry their error and never end the stream.
+	CalculateBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CalculateRequest, BatchResult], error)
+}
+
+type billingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBillingServiceClient(cc grpc.ClientConnInterface) BillingServiceClient {
+	return &billingServiceClient{cc}
+}
+
+func (c *billingServiceClient) Calculate(ctx context.Context, in *CalculateRequest, opts ...grpc.CallOption) (*CalculationResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CalculationResult)
+	err := c.cc.Invoke(ctx, BillingService_Calculate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingServiceClient) GetRates(ctx context.Context, in *GetRatesRequest, opts ...grpc.CallOption) (*GetRatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRatesResponse)
+	err := c.cc.Invoke(ctx, BillingService_GetRates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingServiceClient) GetMonthSummary(ctx context.Context, in *GetMonthSummaryRequest, opts ...grpc.CallOption) (*MonthSummary, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonthSummary)
+	err := c.cc.Invoke(ctx, BillingService_GetMonthSummary_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *billingServiceClient) CalculateBatch(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[CalculateRequest, BatchResult], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BillingService_ServiceDesc.Streams[0], BillingService_CalculateBatch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[CalculateRequest, BatchResult]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BillingService_CalculateBatchClient = grpc.BidiStreamingClient[CalculateRequest, BatchResult]
+
+// BillingServiceServer is the server API for BillingService service.
+// All implementations must embed UnimplementedBillingServiceServer
+// for forward compatibility.
+//
+// BillingService exposes the calculator
+type BillingServiceServer interface {
+	// Calculate a billing amount
+	Calculate(context.Context, *CalculateRequest) (*CalculationResult, error)
+	// Rate table of a profile
+	GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error)
+	// Hours and amount of a whole month
+	GetMonthSummary(context.Context, *GetMonthSummaryRequest) (*MonthSummary, error)
+	// Calculate a stream of jobs. Every request gets a result in order;
+	// failing jobs carry their error and never end the stream.
+	CalculateBatch(grpc.BidiStreamingServer[CalculateRequest, BatchResult]) error
+	mustEmbedUnimplementedBillingServiceServer()
+}
+
+// UnimplementedBillingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBillingServiceServer struct{}
+
+func (UnimplementedBillingServiceServer) Calculate(context.Context, *CalculateRequest) (*CalculationResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Calculate not implemented")
+}
+func (UnimplementedBillingServiceServer) GetRates(context.Context, *GetRatesRequest) (*GetRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRates not implemented")
+}
+func (UnimplementedBillingServiceServer) GetMonthSummary(context.Context, *GetMonthSummaryRequest) (*MonthSummary, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMonthSummary not implemented")
+}
+func (UnimplementedBillingServiceServer) CalculateBatch(grpc.BidiStreamingServer[CalculateRequest, BatchResult]) error {
+	return status.Errorf(codes.Unimplemented, "method CalculateBatch not implemented")
+}
+func (UnimplementedBillingServiceServer) mustEmbedUnimplementedBillingServiceServer() {}
+func (UnimplementedBillingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeBillingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BillingServiceServer will
+// result in compilation errors.
+type UnsafeBillingServiceServer interface {
+	mustEmbedUnimplementedBillingServiceServer()
+}
+
+func RegisterBillingServiceServer(s grpc.ServiceRegistrar, srv BillingServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBillingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BillingService_ServiceDesc, srv)
+}
+
+func _BillingService_Calculate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalculateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).Calculate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingService_Calculate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).Calculate(ctx, req.(*CalculateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingService_GetRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).GetRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingService_GetRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).GetRates(ctx, req.(*GetRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingService_GetMonthSummary_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMonthSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BillingServiceServer).GetMonthSummary(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BillingService_GetMonthSummary_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BillingServiceServer).GetMonthSummary(ctx, req.(*GetMonthSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BillingService_CalculateBatch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BillingServiceServer).CalculateBatch(&grpc.GenericServerStream[CalculateRequest, BatchResult]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BillingService_CalculateBatchServer = grpc.BidiStreamingServer[CalculateRequest, BatchResult]
+
+// BillingService_ServiceDesc is the grpc.ServiceDesc for BillingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BillingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "billctl.v1.BillingService",
+	HandlerType: (*BillingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Calculate",
+			Handler:    _BillingService_Calculate_Handler,
+		},
+		{
+			MethodName: "GetRates",
+			Handler:    _BillingService_GetRates_Handler,
+		},
+		{
+			MethodName: "GetMonthSummary",
+			Handler:    _BillingService_GetMonthSummary_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "CalculateBatch",
+			Handler:       _BillingService_CalculateBatch_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "billctl/v1/billing.proto",
+}
@@ -0,0 +1,278 @@
+package rpc
+
+//go:generate protoc -I ../../proto --go_out=billingpb --go_opt=paths=source_relative --go-grpc_out=billingpb --go-grpc_opt=paths=source_relative billctl/v1/billing.proto
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/internal/rpc/billingpb"
+	"github.com/develpudu/billctl/pkg/billing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server exposes the calculator over gRPC
+type Server struct {
+	billingpb.UnimplementedBillingServiceServer
+
+	Profile func(name string) (*config.BillingConfig, error) // resolves the profile of a request
+	Clock   billing.Clock
+	Check   func(client string, cfg *config.BillingConfig, result *billing.Result) error // rejects results that break the billing policy
+}
+
+// Calculate runs a full calculation
+func (s *Server) Calculate(ctx context.Context, req *billingpb.CalculateRequest) (*billingpb.CalculationResult, error) {
+	return s.calculate(ctx, req)
+}
+
+// GetRates returns the rate table of a profile
+func (s *Server) GetRates(ctx context.Context, req *billingpb.GetRatesRequest) (*billingpb.GetRatesResponse, error) {
+	calc, _, err := s.calculator(req.GetProfile())
+	if err != nil {
+		return nil, err
+	}
+
+	rates := calc.Rates(req.GetCurrency())
+	return &billingpb.GetRatesResponse{
+		Profile:  req.GetProfile(),
+		Currency: rates.Currency,
+		Rates: map[string]float64{
+			"hourly":  rates.Hourly,
+			"daily":   rates.Daily,
+			"weekly":  rates.Weekly,
+			"monthly": rates.Monthly,
+			"annual":  rates.Annual,
+		},
+	}, nil
+}
+
+// GetMonthSummary returns the hours and amount of a whole month
+func (s *Server) GetMonthSummary(ctx context.Context, req *billingpb.GetMonthSummaryRequest) (*billingpb.MonthSummary, error) {
+	calc, cfg, err := s.calculator(req.GetProfile())
+	if err != nil {
+		return nil, err
+	}
+
+	monthInfo, err := calc.Month(ctx, req.GetMonth())
+	if err != nil {
+		return nil, calculationStatus(err)
+	}
+
+	currency := currencyOr(req.GetCurrency(), cfg)
+	summary, err := calc.FormatMonths([]billing.Month{monthInfo}, currency, billing.FormatText)
+	if err != nil {
+		return nil, calculationStatus(err)
+	}
+	return &billingpb.MonthSummary{Month: toMonthInfo(monthInfo), Currency: currency, Summary: strings.TrimSuffix(summary, "\n")}, nil
+}
+
+// CalculateBatch answers every request of the stream with its result, in
+// order. Failing requests carry their error and do not end the stream.
+func (s *Server) CalculateBatch(stream billingpb.BillingService_CalculateBatchServer) error {
+	for index := int32(0); ; index++ {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		result := &billingpb.BatchResult{Index: index}
+		result.Result, err = s.calculate(stream.Context(), req)
+		if err != nil {
+			result.Error = status.Convert(err).Message()
+		}
+		if err := stream.Send(result); err != nil {
+			return err
+		}
+	}
+}
+
+// calculate runs the calculation of a request
+func (s *Server) calculate(ctx context.Context, req *billingpb.CalculateRequest) (*billingpb.CalculationResult, error) {
+	calc, cfg, err := s.calculator(req.GetProfile())
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := calc.Calculate(ctx, toInput(req))
+	if err != nil {
+		return nil, calculationStatus(err)
+	}
+	if s.Check != nil {
+		if err := s.Check(req.GetProfile(), cfg, result); err != nil {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+	}
+	return toCalculationResult(result), nil
+}
+
+// calculator builds a calculator for the named profile
+func (s *Server) calculator(profile string) (*billing.Calculator, *config.BillingConfig, error) {
+	cfg, err := s.Profile(profile)
+	if err != nil {
+		return nil, nil, profileStatus(err)
+	}
+	calc, err := billing.New(billing.WithConfig(cfg), billing.WithClock(s.Clock))
+	if err != nil {
+		return nil, nil, profileStatus(err)
+	}
+	return calc, cfg, nil
+}
+
+// profileStatus reports a profile that could not be loaded: NotFound when
+// it does not exist, FailedPrecondition when its configuration is invalid
+// and Internal for anything else
+func profileStatus(err error) error {
+	switch {
+	case errors.Is(err, config.ErrUnknownProfile):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, config.ErrConfig), errors.Is(err, billing.ErrInvalidConfig):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// calculationStatus reports a calculation that failed: InvalidArgument for
+// invalid input, FailedPrecondition for a profile that cannot bill it and
+// Internal for anything else
+func calculationStatus(err error) error {
+	switch {
+	case errors.Is(err, billing.ErrInvalidInput):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, billing.ErrInvalidConfig):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// currencyOr returns the requested currency or the profile default
+func currencyOr(currency string, cfg *config.BillingConfig) string {
+	if currency != "" {
+		return currency
+	}
+	return cfg.DefaultCurrency
+}
+
+// toInput converts a request into billing input
+func toInput(req *billingpb.CalculateRequest) billing.Input {
+	input := billing.Input{
+		Hours:    req.GetHours(),
+		Months:   req.GetMonths(),
+		From:     req.GetFrom(),
+		To:       req.GetTo(),
+		Currency: req.GetCurrency(),
+	}
+	for _, days := range req.GetDays() {
+		input.Days = append(input.Days, int(days))
+	}
+	for _, weeks := range req.GetWeeks() {
+		input.Weeks = append(input.Weeks, int(weeks))
+	}
+	for _, item := range req.GetItems() {
+		input.Items = append(input.Items, billing.Item{
+			Type:        item.GetType(),
+			Description: item.GetDescription(),
+			Amount:      item.GetAmount(),
+			Percent:     item.GetPercent(),
+		})
+	}
+	for _, expense := range req.GetExpenses() {
+		input.Expenses = append(input.Expenses, billing.Expense{
+			Description: expense.GetDescription(),
+			Amount:      expense.GetAmount(),
+			Currency:    expense.GetCurrency(),
+			Markup:      expense.Markup,
+			Receipt:     expense.GetReceipt(),
+		})
+	}
+	return input
+}
+
+// toMonthInfo converts a month breakdown into its message
+func toMonthInfo(monthInfo billing.Month) *billingpb.MonthInfo {
+	return &billingpb.MonthInfo{
+		Input:      monthInfo.Input,
+		Days:       int32(monthInfo.Days),
+		Year:       int32(monthInfo.Year),
+		Month:      int32(monthInfo.Month),
+		Hours:      monthInfo.Hours,
+		HourlyRate: monthInfo.HourlyRate,
+		Amount:     monthInfo.Amount,
+		From:       monthInfo.From,
+		To:         monthInfo.To,
+	}
+}
+
+// toCalculationResult converts a calculation result into its message
+func toCalculationResult(result *billing.Result) *billingpb.CalculationResult {
+	msg := &billingpb.CalculationResult{
+		TotalWeeks:    int32(result.TotalWeeks),
+		TotalDays:     int32(result.TotalDays),
+		TotalHours:    result.TotalHours,
+		TotalTime:     result.TotalTime,
+		BilledHours:   result.BilledHours,
+		LaborAmount:   result.LaborAmount,
+		ItemsAmount:   result.ItemsAmount,
+		ExpenseTotal:  result.ExpenseTotal,
+		LaborTax:      result.LaborTax,
+		ExpenseTax:    result.ExpenseTax,
+		TotalAmount:   result.TotalAmount,
+		Currency:      result.Currency,
+		HourlyRate:    result.HourlyRate,
+		WeeksStrategy: result.WeeksStrategy,
+		WeeksPerMonth: result.WeeksPerMonth,
+	}
+	for _, monthInfo := range result.Months {
+		msg.MonthDetails = append(msg.MonthDetails, toMonthInfo(monthInfo))
+	}
+	for _, adjustment := range result.Adjustments {
+		msg.Adjustments = append(msg.Adjustments, &billingpb.Adjustment{
+			Description: adjustment.Description,
+			Hours:       adjustment.Hours,
+			Amount:      adjustment.Amount,
+		})
+	}
+	for _, item := range result.LineItems {
+		msg.LineItems = append(msg.LineItems, &billingpb.LineItem{
+			Type:        item.Type,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+		})
+	}
+	for _, expense := range result.Expenses {
+		msg.Expenses = append(msg.Expenses, &billingpb.ExpenseLine{
+			Description:      expense.Description,
+			Receipt:          expense.Receipt,
+			OriginalAmount:   expense.OriginalAmount,
+			OriginalCurrency: expense.OriginalCurrency,
+			ExchangeRate:     expense.ExchangeRate,
+			Amount:           expense.Amount,
+			Markup:           expense.Markup,
+			MarkupAmount:     expense.MarkupAmount,
+			Total:            expense.Total,
+		})
+	}
+	if retainer := result.Retainer; retainer != nil {
+		msg.Retainer = &billingpb.RetainerSummary{
+			OpeningHours:   retainer.OpeningHours,
+			DrawnHours:     retainer.DrawnHours,
+			ExpiredHours:   retainer.ExpiredHours,
+			RemainingHours: retainer.RemainingHours,
+			OverageHours:   retainer.OverageHours,
+			OverageRate:    retainer.OverageRate,
+			OverageAmount:  retainer.OverageAmount,
+		}
+	}
+	return msg
+}
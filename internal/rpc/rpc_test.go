@@ -0,0 +1,247 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/internal/rpc/billingpb"
+	"github.com/develpudu/billctl/pkg/billing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient serves the default profile plus an hourly "acme" profile
+// over an in-memory connection
+func newTestClient(t *testing.T, check func(string, *config.BillingConfig, *billing.Result) error) billingpb.BillingServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1 << 20)
+
+	grpcServer := grpc.NewServer()
+	billingpb.RegisterBillingServiceServer(grpcServer, &Server{
+		Profile: func(name string) (*config.BillingConfig, error) {
+			cfg := config.NewBillingConfig()
+			switch name {
+			case "":
+			case "acme":
+				if err := cfg.SetRate(config.AnchorHourly, 25); err != nil {
+					return nil, err
+				}
+				cfg.DefaultCurrency = "EUR"
+			case "broken":
+				return nil, &config.FieldError{Field: "rate_amount", Value: "-1", Err: fmt.Errorf("rate_amount must be positive")}
+			case "offline":
+				return nil, fmt.Errorf("config store unavailable")
+			default:
+				return nil, fmt.Errorf("%w: %s", config.ErrUnknownProfile, name)
+			}
+			return cfg, nil
+		},
+		Clock: calculator.FixedClock{Time: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+		Check: check,
+	})
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to dial bufnet: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return billingpb.NewBillingServiceClient(conn)
+}
+
+func TestCalculate(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	tests := []struct {
+		name          string
+		req           *billingpb.CalculateRequest
+		expectedCode  codes.Code
+		expectedTotal float64
+	}{
+		{"hours", &billingpb.CalculateRequest{Hours: []float64{10}}, codes.OK, 137.5},
+		{"profile", &billingpb.CalculateRequest{Profile: "acme", Hours: []float64{10}, Currency: "USD"}, codes.OK, 250},
+		{"months and items", &billingpb.CalculateRequest{
+			Months: []string{"2024-02"},
+			Items:  []*billingpb.ItemInput{{Type: "fixed", Description: "Setup", Amount: 500}},
+		}, codes.OK, 232*13.75 + 500},
+		{"date range", &billingpb.CalculateRequest{From: "2026-09-28", To: "2026-10-02"}, codes.OK, 40 * 13.75},
+		{"relative period", &billingpb.CalculateRequest{Months: []string{"last-month"}}, codes.OK, 240 * 13.75},
+		{"negative hours", &billingpb.CalculateRequest{Hours: []float64{-1}}, codes.InvalidArgument, 0},
+		{"invalid month", &billingpb.CalculateRequest{Months: []string{"2024-13"}}, codes.InvalidArgument, 0},
+		{"missing exchange rate", &billingpb.CalculateRequest{
+			Hours:    []float64{1},
+			Expenses: []*billingpb.ExpenseInput{{Description: "Taxi", Amount: 30, Currency: "GBP"}},
+		}, codes.FailedPrecondition, 0},
+		{"unknown profile", &billingpb.CalculateRequest{Profile: "nobody", Hours: []float64{1}}, codes.NotFound, 0},
+		{"invalid profile", &billingpb.CalculateRequest{Profile: "broken", Hours: []float64{1}}, codes.FailedPrecondition, 0},
+		{"unreadable profile", &billingpb.CalculateRequest{Profile: "offline", Hours: []float64{1}}, codes.Internal, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := client.Calculate(context.Background(), test.req)
+			if code := status.Code(err); code != test.expectedCode {
+				t.Fatalf("Calculate() code = %v, want %v (err: %v)", code, test.expectedCode, err)
+			}
+			if err != nil {
+				return
+			}
+			if math.Abs(result.GetTotalAmount()-test.expectedTotal) > 0.001 {
+				t.Errorf("Calculate() total = %v, want %v", result.GetTotalAmount(), test.expectedTotal)
+			}
+		})
+	}
+}
+
+func TestCalculateExpenseMarkup(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	markup := 10.0
+	result, err := client.Calculate(context.Background(), &billingpb.CalculateRequest{
+		Expenses: []*billingpb.ExpenseInput{{Description: "AWS", Amount: 100, Markup: &markup}},
+	})
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if len(result.GetExpenses()) != 1 || result.GetExpenses()[0].GetTotal() != 110 {
+		t.Errorf("Calculate() expenses = %v, want one expense of 110", result.GetExpenses())
+	}
+}
+
+func TestCalculatePolicy(t *testing.T) {
+	client := newTestClient(t, func(_ string, _ *config.BillingConfig, result *billing.Result) error {
+		if result.HourlyRate < 20 {
+			return fmt.Errorf("hourly rate %.2f is below the minimum of 20.00", result.HourlyRate)
+		}
+		return nil
+	})
+
+	_, err := client.Calculate(context.Background(), &billingpb.CalculateRequest{Hours: []float64{10}})
+	if status.Code(err) != codes.FailedPrecondition || !strings.Contains(err.Error(), "below the minimum") {
+		t.Errorf("Calculate() error = %v, want a failed precondition for the minimum rate", err)
+	}
+
+	if _, err := client.Calculate(context.Background(), &billingpb.CalculateRequest{Profile: "acme", Hours: []float64{10}}); err != nil {
+		t.Errorf("Calculate() unexpected error: %v", err)
+	}
+}
+
+func TestGetRates(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	resp, err := client.GetRates(context.Background(), &billingpb.GetRatesRequest{Profile: "acme"})
+	if err != nil {
+		t.Fatalf("GetRates() unexpected error: %v", err)
+	}
+	if resp.GetCurrency() != "EUR" || resp.GetRates()["hourly"] != 25 {
+		t.Errorf("GetRates() = %v, want EUR with an hourly rate of 25", resp)
+	}
+
+	tests := []struct {
+		profile      string
+		expectedCode codes.Code
+	}{
+		{"nobody", codes.NotFound},
+		{"broken", codes.FailedPrecondition},
+		{"offline", codes.Internal},
+	}
+	for _, test := range tests {
+		t.Run(test.profile, func(t *testing.T) {
+			_, err := client.GetRates(context.Background(), &billingpb.GetRatesRequest{Profile: test.profile})
+			if status.Code(err) != test.expectedCode {
+				t.Errorf("GetRates() code = %v, want %v", status.Code(err), test.expectedCode)
+			}
+		})
+	}
+}
+
+func TestGetMonthSummary(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	resp, err := client.GetMonthSummary(context.Background(), &billingpb.GetMonthSummaryRequest{Month: "2024-02"})
+	if err != nil {
+		t.Fatalf("GetMonthSummary() unexpected error: %v", err)
+	}
+	if resp.GetMonth().GetDays() != 29 || resp.GetMonth().GetHours() != 232 {
+		t.Errorf("GetMonthSummary() month = %v, want 29 days and 232 hours", resp.GetMonth())
+	}
+	if !strings.Contains(resp.GetSummary(), "U$S") {
+		t.Errorf("GetMonthSummary() summary = %q, want the default currency", resp.GetSummary())
+	}
+
+	_, err = client.GetMonthSummary(context.Background(), &billingpb.GetMonthSummaryRequest{Month: "2024-13"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("GetMonthSummary() code = %v, want %v", status.Code(err), codes.InvalidArgument)
+	}
+}
+
+func TestCalculateBatch(t *testing.T) {
+	client := newTestClient(t, nil)
+
+	stream, err := client.CalculateBatch(context.Background())
+	if err != nil {
+		t.Fatalf("CalculateBatch() unexpected error: %v", err)
+	}
+
+	requests := []*billingpb.CalculateRequest{
+		{Hours: []float64{10}},
+		{Profile: "nobody", Hours: []float64{1}},
+		{Profile: "acme", Hours: []float64{4}},
+	}
+	for _, req := range requests {
+		if err := stream.Send(req); err != nil {
+			t.Fatalf("Send() unexpected error: %v", err)
+		}
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("CloseSend() unexpected error: %v", err)
+	}
+
+	var results []*billingpb.BatchResult
+	for {
+		result, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Recv() unexpected error: %v", err)
+		}
+		results = append(results, result)
+	}
+
+	if len(results) != len(requests) {
+		t.Fatalf("CalculateBatch() returned %d results, want %d", len(results), len(requests))
+	}
+	for i, result := range results {
+		if result.GetIndex() != int32(i) {
+			t.Errorf("results[%d].Index = %d, want %d", i, result.GetIndex(), i)
+		}
+	}
+	if results[0].GetResult().GetTotalAmount() != 137.5 {
+		t.Errorf("results[0] total = %v, want 137.5", results[0].GetResult().GetTotalAmount())
+	}
+	if !strings.Contains(results[1].GetError(), "unknown profile") || results[1].GetResult() != nil {
+		t.Errorf("results[1] = %v, want an unknown profile error", results[1])
+	}
+	if results[2].GetResult().GetTotalAmount() != 100 {
+		t.Errorf("results[2] total = %v, want 100", results[2].GetResult().GetTotalAmount())
+	}
+}
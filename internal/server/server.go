@@ -0,0 +1,241 @@
+package server
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/pkg/billing"
+)
+
+//go:embed openapi.json
+var openAPI []byte
+
+// maxBodySize limits the size of request bodies
+const maxBodySize = 1 << 20
+
+// CalculateRequest is the body of POST /v1/calculate. It mirrors
+// billing.Input plus the profile to use.
+type CalculateRequest struct {
+	Profile  string            `json:"profile,omitempty"`
+	Currency string            `json:"currency,omitempty"`
+	Hours    []float64         `json:"hours,omitempty"`
+	Days     []int             `json:"days,omitempty"`
+	Weeks    []int             `json:"weeks,omitempty"`
+	Months   []string          `json:"months,omitempty"`
+	From     string            `json:"from,omitempty"`
+	To       string            `json:"to,omitempty"`
+	Items    []billing.Item    `json:"items,omitempty"`
+	Expenses []billing.Expense `json:"expenses,omitempty"`
+}
+
+// RatesResponse is the body returned by GET /v1/rates
+type RatesResponse struct {
+	Profile string `json:"profile,omitempty"`
+	billing.Rates
+}
+
+// MonthResponse is the body returned by GET /v1/months/{yyyy-mm}
+type MonthResponse struct {
+	billing.Month
+	Currency string `json:"currency"`
+	Summary  string `json:"summary"`
+}
+
+// ErrorResponse is the body returned for failed requests
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server exposes the calculator over HTTP
+type Server struct {
+	Profile func(name string) (*config.BillingConfig, error) // resolves the profile of a request
+	Clock   billing.Clock
+	Check   func(client string, cfg *config.BillingConfig, result *billing.Result) error // rejects results that break the billing policy
+}
+
+// Handler returns the HTTP handler with every route registered
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/calculate", s.handleCalculate)
+	mux.HandleFunc("/v1/rates", s.handleRates)
+	mux.HandleFunc("/v1/months/", s.handleMonth)
+	mux.HandleFunc("/openapi.json", s.handleOpenAPI)
+	return mux
+}
+
+// handleCalculate runs a full calculation
+func (s *Server) handleCalculate(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodPost) {
+		return
+	}
+
+	var req CalculateRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request body: %v", err))
+		return
+	}
+
+	calc, cfg, err := s.calculator(req.Profile)
+	if err != nil {
+		writeProfileError(w, err)
+		return
+	}
+
+	result, err := calc.Calculate(r.Context(), billing.Input{
+		Hours:    req.Hours,
+		Days:     req.Days,
+		Weeks:    req.Weeks,
+		Months:   req.Months,
+		From:     req.From,
+		To:       req.To,
+		Items:    req.Items,
+		Expenses: req.Expenses,
+		Currency: req.Currency,
+	})
+	if err != nil {
+		writeCalculationError(w, err)
+		return
+	}
+	if s.Check != nil {
+		if err := s.Check(req.Profile, cfg, result); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleRates returns the rate table of a profile
+func (s *Server) handleRates(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	query := r.URL.Query()
+	calc, _, err := s.calculator(query.Get("profile"))
+	if err != nil {
+		writeProfileError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, RatesResponse{Profile: query.Get("profile"), Rates: calc.Rates(query.Get("currency"))})
+}
+
+// handleMonth returns the hours and amount of a whole month
+func (s *Server) handleMonth(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+
+	month := strings.TrimPrefix(r.URL.Path, "/v1/months/")
+	if month == "" || strings.Contains(month, "/") {
+		writeError(w, http.StatusNotFound, fmt.Errorf("not found: %s", r.URL.Path))
+		return
+	}
+
+	query := r.URL.Query()
+	calc, cfg, err := s.calculator(query.Get("profile"))
+	if err != nil {
+		writeProfileError(w, err)
+		return
+	}
+
+	monthInfo, err := calc.Month(r.Context(), month)
+	if err != nil {
+		writeCalculationError(w, err)
+		return
+	}
+
+	currency := currencyOr(query.Get("currency"), cfg)
+	summary, err := calc.FormatMonths([]billing.Month{monthInfo}, currency, billing.FormatText)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, MonthResponse{Month: monthInfo, Currency: currency, Summary: strings.TrimSuffix(summary, "\n")})
+}
+
+// handleOpenAPI serves the OpenAPI document of the API
+func (s *Server) handleOpenAPI(w http.ResponseWriter, r *http.Request) {
+	if !allowMethod(w, r, http.MethodGet) {
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPI)
+}
+
+// calculator builds a calculator for the named profile
+func (s *Server) calculator(profile string) (*billing.Calculator, *config.BillingConfig, error) {
+	cfg, err := s.Profile(profile)
+	if err != nil {
+		return nil, nil, err
+	}
+	calc, err := billing.New(billing.WithConfig(cfg), billing.WithClock(s.Clock))
+	if err != nil {
+		return nil, nil, err
+	}
+	return calc, cfg, nil
+}
+
+// currencyOr returns the requested currency or the profile default
+func currencyOr(currency string, cfg *config.BillingConfig) string {
+	if currency != "" {
+		return currency
+	}
+	return cfg.DefaultCurrency
+}
+
+// allowMethod rejects requests with any other method
+func allowMethod(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method == method {
+		return true
+	}
+	w.Header().Set("Allow", method)
+	writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+	return false
+}
+
+// writeProfileError reports a profile that could not be resolved: 404 for
+// an unknown profile, 422 for an invalid one and 500 for anything else
+func writeProfileError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, config.ErrUnknownProfile):
+		writeError(w, http.StatusNotFound, err)
+	case errors.Is(err, config.ErrConfig):
+		writeError(w, http.StatusUnprocessableEntity, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// writeCalculationError reports a calculation that failed: 400 for invalid
+// input, 422 for a profile that cannot bill it and 500 for anything else
+func writeCalculationError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, billing.ErrInvalidInput):
+		writeError(w, http.StatusBadRequest, err)
+	case errors.Is(err, billing.ErrInvalidConfig):
+		writeError(w, http.StatusUnprocessableEntity, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+// writeError writes an error response
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, ErrorResponse{Error: err.Error()})
+}
+
+// writeJSON writes a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
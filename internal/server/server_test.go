@@ -0,0 +1,222 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/pkg/billing"
+)
+
+// newTestServer serves the default profile plus an hourly "acme" profile,
+// an invalid "broken" profile and an "offline" profile that cannot be read
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	api := &Server{
+		Profile: func(name string) (*config.BillingConfig, error) {
+			cfg := config.NewBillingConfig()
+			switch name {
+			case "":
+			case "acme":
+				if err := cfg.SetRate(config.AnchorHourly, 25); err != nil {
+					return nil, err
+				}
+				cfg.DefaultCurrency = "EUR"
+			case "broken":
+				cfg.RateAnchor = "hourlyish"
+				return nil, fmt.Errorf("invalid profile %s: %w", name, cfg.Validate())
+			case "offline":
+				return nil, errors.New("config file unreadable")
+			default:
+				return nil, fmt.Errorf("%w: %s", config.ErrUnknownProfile, name)
+			}
+			return cfg, nil
+		},
+		Clock: calculator.FixedClock{Time: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)},
+	}
+	server := httptest.NewServer(api.Handler())
+	t.Cleanup(server.Close)
+	return server
+}
+
+// decode reads a JSON response body
+func decode(t *testing.T, resp *http.Response, v interface{}) {
+	t.Helper()
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %s, want application/json", ct)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+		expectedTotal  float64
+		expectedError  string
+	}{
+		{"hours", `{"hours": [10]}`, http.StatusOK, 137.5, ""},
+		{"profile", `{"profile": "acme", "hours": [10], "currency": "USD"}`, http.StatusOK, 250, ""},
+		{"months and items", `{"months": ["2024-02"], "items": [{"type": "fixed", "description": "Setup", "amount": 500}]}`, http.StatusOK, 232*13.75 + 500, ""},
+		{"date range", `{"from": "2026-09-28", "to": "2026-10-02"}`, http.StatusOK, 40 * 13.75, ""},
+		{"relative period", `{"months": ["last-month"]}`, http.StatusOK, 240 * 13.75, ""},
+		{"negative hours", `{"hours": [-1]}`, http.StatusBadRequest, 0, "hours cannot be negative"},
+		{"invalid month", `{"months": ["2024-13"]}`, http.StatusBadRequest, 0, "invalid month"},
+		{"unknown field", `{"hourz": [1]}`, http.StatusBadRequest, 0, "unknown field"},
+		{"malformed", `{`, http.StatusBadRequest, 0, "invalid request body"},
+		{"missing exchange rate", `{"hours": [1], "expenses": [{"description": "Taxi", "amount": 30, "currency": "GBP"}]}`, http.StatusUnprocessableEntity, 0, "no exchange rate"},
+		{"unknown profile", `{"profile": "nobody", "hours": [1]}`, http.StatusNotFound, 0, "unknown profile"},
+		{"invalid profile", `{"profile": "broken", "hours": [1]}`, http.StatusUnprocessableEntity, 0, "invalid rate anchor"},
+		{"unreadable profile", `{"profile": "offline", "hours": [1]}`, http.StatusInternalServerError, 0, "unreadable"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp, err := http.Post(server.URL+"/v1/calculate", "application/json", strings.NewReader(test.body))
+			if err != nil {
+				t.Fatalf("POST /v1/calculate failed: %v", err)
+			}
+			if resp.StatusCode != test.expectedStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, test.expectedStatus)
+			}
+
+			if test.expectedError != "" {
+				var body ErrorResponse
+				decode(t, resp, &body)
+				if !strings.Contains(body.Error, test.expectedError) {
+					t.Errorf("error = %q, want it to contain %q", body.Error, test.expectedError)
+				}
+				return
+			}
+
+			var result billing.Result
+			decode(t, resp, &result)
+			if diff := result.TotalAmount - test.expectedTotal; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("total_amount = %.2f, want %.2f", result.TotalAmount, test.expectedTotal)
+			}
+		})
+	}
+}
+
+func TestRates(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := http.Get(server.URL + "/v1/rates?profile=acme")
+	if err != nil {
+		t.Fatalf("GET /v1/rates failed: %v", err)
+	}
+	var body RatesResponse
+	decode(t, resp, &body)
+
+	if body.Currency != "EUR" || body.Profile != "acme" {
+		t.Errorf("rates response = %+v, want acme in EUR", body)
+	}
+	if body.Hourly != 25 || body.Daily != 200 {
+		t.Errorf("rates = %+v, want hourly 25 and daily 200", body.Rates)
+	}
+
+	resp, err = http.Get(server.URL + "/v1/rates?profile=nobody")
+	if err != nil {
+		t.Fatalf("GET /v1/rates failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("unknown profile status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestMonth(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		path           string
+		expectedStatus int
+		expectedHours  float64
+		expectedAmount float64
+	}{
+		{"/v1/months/2024-02", http.StatusOK, 232, 3190},
+		{"/v1/months/02", http.StatusOK, 224, 3080}, // February 2026
+		{"/v1/months/2024-02?profile=acme&currency=USD", http.StatusOK, 232, 5800},
+		{"/v1/months/2024-13", http.StatusBadRequest, 0, 0},
+		{"/v1/months/", http.StatusNotFound, 0, 0},
+		{"/v1/months/2024-02/extra", http.StatusNotFound, 0, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			resp, err := http.Get(server.URL + test.path)
+			if err != nil {
+				t.Fatalf("GET %s failed: %v", test.path, err)
+			}
+			if resp.StatusCode != test.expectedStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, test.expectedStatus)
+			}
+			if test.expectedStatus != http.StatusOK {
+				resp.Body.Close()
+				return
+			}
+
+			var body MonthResponse
+			decode(t, resp, &body)
+			if body.Hours != test.expectedHours || body.Amount != test.expectedAmount {
+				t.Errorf("month = %g hours, %.2f, want %g hours, %.2f", body.Hours, body.Amount, test.expectedHours, test.expectedAmount)
+			}
+			if !strings.Contains(body.Summary, fmt.Sprintf("%s %.2f", body.Currency, body.Amount)) {
+				t.Errorf("summary = %q does not match the amount", body.Summary)
+			}
+		})
+	}
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	server := newTestServer(t)
+
+	for _, path := range []string{"/v1/calculate", "/v1/rates", "/v1/months/2024-02", "/openapi.json"} {
+		method := http.MethodDelete
+		req, _ := http.NewRequest(method, server.URL+path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s failed: %v", method, path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusMethodNotAllowed || resp.Header.Get("Allow") == "" {
+			t.Errorf("%s %s status = %d, want %d with an Allow header", method, path, resp.StatusCode, http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := http.Get(server.URL + "/openapi.json")
+	if err != nil {
+		t.Fatalf("GET /openapi.json failed: %v", err)
+	}
+	var doc struct {
+		OpenAPI string                     `json:"openapi"`
+		Paths   map[string]json.RawMessage `json:"paths"`
+	}
+	decode(t, resp, &doc)
+
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		t.Errorf("openapi = %q, want a 3.x document", doc.OpenAPI)
+	}
+	for _, path := range []string{"/v1/calculate", "/v1/rates", "/v1/months/{month}", "/openapi.json"} {
+		if _, ok := doc.Paths[path]; !ok {
+			t.Errorf("OpenAPI document is missing path %s", path)
+		}
+	}
+}
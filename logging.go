@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Logging flags
+var (
+	logLevel  string
+	logFormat string
+	explain   bool
+)
+
+// Log formats
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// newLogger builds the logger selected by --log-level, --log-format and
+// --explain. --explain traces every calculation step at debug level.
+func newLogger(w io.Writer) (*slog.Logger, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(logLevel)); err != nil {
+		return nil, usageError{fmt.Errorf("invalid log level: %s (use debug, info, warn or error)", logLevel)}
+	}
+	if explain {
+		level = slog.LevelDebug
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+	switch strings.ToLower(logFormat) {
+	case logFormatText:
+		return slog.New(slog.NewTextHandler(w, options)), nil
+	case logFormatJSON:
+		return slog.New(slog.NewJSONHandler(w, options)), nil
+	default:
+		return nil, usageError{fmt.Errorf("invalid log format: %s (use text or json)", logFormat)}
+	}
+}
+
+// setupLogging installs the logger of the command line flags as the default
+func setupLogging(cmd *cobra.Command, w io.Writer) error {
+	logger, err := newLogger(w)
+	if err != nil {
+		silenceUsageForJSON(cmd)
+		return err
+	}
+	slog.SetDefault(logger)
+	return nil
+}
@@ -1,13 +1,23 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // --tz works without a system zoneinfo database
 
-	"billctl/internal/calculator"
-	"billctl/internal/config"
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/internal/policy"
+	"github.com/develpudu/billctl/pkg/billing"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // Version information (set during build)
@@ -19,13 +29,30 @@ var (
 
 // Command line flags
 var (
-	hours       []int
-	days        []int
-	weeks       []int
-	months      []string
+	given       inputFlags
+	projects    []string
+	people      []string
+	fxRates     []string
+	rate        string
+	weeksMode   string
 	currency    string
 	showRates   bool
+	outputMode  string
 	showVersion bool
+	configPath  string
+	client      string
+	ledgerPath  string
+
+	// Profile resolved from --client or the config file default
+	activeProfile string
+
+	// Current date overrides
+	todayDate string
+	timezone  string
+
+	// Billing policy guardrails
+	policyPath     string
+	overrideReason string
 )
 
 var rootCmd = &cobra.Command{
@@ -37,108 +64,793 @@ A CLI tool for calculating billing amounts based on hours, days, weeks, and mont
 You can combine multiple time periods for complex calculations.
 
 Examples:
-  billctl -h 120                       # Calculate for 120 hours
-  billctl -d 15                        # Calculate for 15 days
-  billctl -s 2                         # Calculate for 2 weeks
-  billctl -m 02                        # February of current year
-  billctl -m 2024-02                   # February 2024 (28 days)
-  billctl -m 01 -d 5                   # January + 5 additional days
-  billctl -s 2 -d 3 -h 4               # 2 weeks + 3 days + 4 hours
-  billctl -d 15 --currency EUR         # 15 days in euros
-  billctl -m 2024-01 -m 2024-02        # Multiple months
+  billctl calc -h 120                  # Calculate for 120 hours
+  billctl calc -d 15                   # Calculate for 15 days
+  billctl calc -s 2                    # Calculate for 2 weeks
+  billctl calc -m 02                   # February of current year
+  billctl calc -m 2024-02              # February 2024 (29 days)
+  billctl calc -m 01 -d 5              # January + 5 additional days
+  billctl calc -s 2 -d 3 -h 4          # 2 weeks + 3 days + 4 hours
+  billctl calc -d 15 --currency EUR    # 15 days in euros
+  billctl calc -m 2024-01 -m 2024-02   # Multiple months
+  billctl calc -h 20 --fixed "Setup:500"
+  billctl calc --milestone "Design:25:8000"
+  billctl rates --client acme          # Rate table of a profile
+  billctl month 2024-02                # Hours and amount of a whole month
+
+The calc flags also work without the subcommand (billctl -h 120), as do
+--rates and --version. Set BILLCTL_WARN_LEGACY=1 to be warned on each use.
 
 Month formats:
   MM                                   # Month of current year (e.g., 02 for February)
   YYYY-MM                              # Month of specific year (e.g., 2024-02)
+  YYYY-Qn                              # Quarter (e.g., 2026-Q3)
+  YYYY-Wnn                             # ISO week, billed by workdays (e.g., 2026-W41)
+  FROM..TO                             # Months (2026-01..2026-03) or dates (2026-09-12..2026-10-11)
+  feb, febrero, feb-2026               # Month names in English or Spanish
+  this-month, last-month               # Relative to today
+  this-quarter, last-quarter, ytd      # Quarters and year to date
+
+Date ranges:
+  --from YYYY-MM-DD --to YYYY-MM-DD    # Workdays between two dates, split per month
+  --to-exclusive                       # Do not bill the --to date itself
+
+Line items:
+  --fixed DESC:AMOUNT                  # Fixed fee
+  --milestone DESC:PERCENT:TOTAL       # Percentage of a project total
+  --recurring DESC:AMOUNT              # Monthly fee, charged per billed month
+
+Expenses:
+  --expense DESC:AMOUNT[:CURRENCY]     # Reimbursable expense (optional :markup=10 :receipt=FILE)
+  --expenses FILE                      # Load expenses from a CSV file
+  --fx FROM/TO=RATE                    # Exchange rate for expense conversion
 
 Supported operations:
-  --rates                              # Show rate table
   --currency CURRENCY                  # Set currency (default: U$S)
-  --version                            # Show version information
-  --help, -?                           # Show help message`,
+  --rate AMOUNT/UNIT                   # Quote the rate per h, d, w, m or y (e.g. 25/h)
+  --weeks-per-month STRATEGY           # fixed, average (52/12), calendar or working
+  --today YYYY-MM-DD                   # Resolve MM, last-month, ytd... against this date
+  --tz ZONE                            # Time zone for the current date (e.g. America/Argentina/Buenos_Aires)
+  --output, -o FORMAT                  # Output format: text, json or csv
+  --config FILE                        # Load profiles from a JSON config file
+  --client PROFILE                     # Use a named profile from the config file
+  --project NAME                       # Bill the following input to a project of the profile
+  --person NAME                        # Bill the following input as work of a team member
+  --ledger FILE                        # Track a retainer hour bank across runs
+  --policy FILE                        # Block results that break a billing policy
+  --override-policy REASON             # Print despite violations, recording the reason
+  --log-level LEVEL                    # debug, info, warn (default) or error
+  --log-format FORMAT                  # Log format: text or json
+  --explain                            # Trace every calculation step to stderr
+
+Commands:
+  calc                                 # Calculate a billing amount
+  rates                                # Show the rate table
+  month MONTH...                       # Hours and amount of whole months
+  config                               # Show the resolved configuration
+  version                              # Show version information
+  batch FILE                           # Calculate every row of a CSV or JSON jobs file
+  interactive                          # Guided prompts instead of flags
+  serve --addr :8080                   # Serve the calculator as an HTTP API
+  grpc --addr :9090                    # Serve the calculator as a gRPC service
+  completion bash|zsh|fish|powershell  # Generate the shell completion script
+  --help, -?                           # Show help message
+
+Exit codes:
+  1 unexpected error, 2 usage, 3 invalid input, 4 invalid configuration,
+  5 policy violation. With --output json errors are written to stderr as
+  {"error": {"code", "message", "field", "value", "exit_code"}}.`,
+	Args: usageArgs(cobra.NoArgs),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Check for manual help flag
 		if help, _ := cmd.Flags().GetBool("help"); help {
 			return cmd.Help()
 		}
 
-		// Initialize configuration
-		cfg := config.NewBillingConfig()
-		if err := cfg.Validate(); err != nil {
-			return fmt.Errorf("configuration error: %v", err)
+		// The flag forms predate the subcommands and keep working as shortcuts
+		if showVersion {
+			warnLegacy("version")
+			return printVersion()
 		}
+		if showRates {
+			warnLegacy("rates")
+			return runRates()
+		}
+		if !given.empty() {
+			warnLegacy("calc")
+		}
+		return runCalc(cmd)
+	},
+}
 
-		// Initialize calculator
-		calc := calculator.NewCalculator(cfg)
+// warnLegacy points scripts using the flag shortcuts to the equivalent
+// subcommand when $BILLCTL_WARN_LEGACY is set
+func warnLegacy(subcommand string) {
+	if os.Getenv("BILLCTL_WARN_LEGACY") == "" {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "Advertencia: la forma sin subcomando está en desuso, usar 'billctl %s'\n", subcommand)
+}
 
-		// If --version flag is set, show version and exit
-		if showVersion {
-			fmt.Printf("Billctl v%s\n", Version)
-			fmt.Printf("Build Time: %s\n", BuildTime)
-			fmt.Printf("Git Commit: %s\n", GitCommit)
-			fmt.Printf("Go Version: %s\n", "go1.21+")
-			return nil
+// resolveConfig loads the billing configuration and applies the profile
+// flags. Problems with the flags or the profile are returned to be reported
+// together with those of the input.
+func resolveConfig() (*config.BillingConfig, []error, error) {
+	cfg, err := loadConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("configuration error: %w", err)
+	}
+	var problems []error
+	if rate != "" {
+		if anchor, amount, err := config.ParseRate(rate); err != nil {
+			problems = append(problems, err)
+		} else if err := cfg.SetRate(anchor, amount); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if weeksMode != "" {
+		if err := cfg.SetWeeksStrategy(weeksMode); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	problems = append(problems, applyExchangeRates(cfg)...)
+	problems = append(problems, cfg.Problems()...)
+	for i, problem := range problems {
+		problems[i] = fmt.Errorf("configuration error: %w", problem)
+	}
+	if err := calculator.ValidateOutput(outputMode); err != nil {
+		problems = append(problems, err)
+	}
+	return cfg, problems, nil
+}
+
+// newBilling builds the billing calculator for cfg on clock, adjusted by
+// opts. With problems in the profile the input is still checked, against the
+// defaults, so that every problem is reported at once.
+func newBilling(cfg *config.BillingConfig, clock billing.Clock, problems []error, opts ...billing.Option) (*billing.Calculator, error) {
+	profile := []billing.Option{billing.WithClock(clock)}
+	if len(problems) == 0 {
+		profile = append(profile, billing.WithConfig(cfg))
+	}
+	return billing.New(append(profile, opts...)...)
+}
+
+// runCalc calculates the time, line items and expenses given as flags,
+// showing the help of cmd when none was given
+func runCalc(cmd *cobra.Command) error {
+	// The recorded flag order belongs to this run only
+	defer func() { givenOrder = nil }()
+
+	// Initialize configuration and calculator
+	cfg, problems, err := resolveConfig()
+	if err != nil {
+		return err
+	}
+	clock, err := buildClock()
+	if err != nil {
+		return err
+	}
+
+	// Check if any time parameters or line items were provided
+	if given.empty() {
+		if len(problems) > 0 {
+			return &calculator.ValidationError{Errors: problems}
 		}
+		return cmd.Help()
+	}
 
-		// If --rates flag is set, show rates and exit
-		if showRates {
-			fmt.Print(calc.FormatRates(currency))
-			return nil
+	var opts []billing.Option
+	if ledgerPath != "" {
+		bank, err := loadLedger()
+		if err != nil {
+			return fmt.Errorf("ledger error: %v", err)
 		}
+		opts = append(opts, billing.WithRetainerBank(bank))
+	}
 
-		// Check if any time parameters were provided
-		if len(hours) == 0 && len(days) == 0 && len(weeks) == 0 && len(months) == 0 {
-			return cmd.Help()
+	// Prepare input, one per project
+	for _, name := range projects {
+		if _, err := cfg.ForProject(name); err != nil {
+			problems = append(problems, fmt.Errorf("configuration error: %w", err))
+		}
+	}
+	for _, name := range people {
+		if _, err := cfg.ForPerson(name); err != nil {
+			problems = append(problems, fmt.Errorf("configuration error: %w", err))
+		}
+	}
+	inputs, inputProblems := taggedInputs()
+	problems = append(problems, inputProblems...)
+	calc, err := newBilling(cfg, clock, problems, opts...)
+	if err != nil {
+		// The profile was checked by resolveConfig, so only the ledger can fail
+		return fmt.Errorf("ledger error: %v", err)
+	}
+
+	// Report every problem at once
+	var validationErr *calculator.ValidationError
+	if err := calc.Validate(inputs...); errors.As(err, &validationErr) {
+		problems = append(problems, validationErr.Errors...)
+	}
+	if len(problems) > 0 {
+		return &calculator.ValidationError{Errors: problems}
+	}
+
+	// A single project is billed in its own currency unless --currency is given
+	invoiceCurrency := currency
+	if len(projects) == 1 && !cmd.Flags().Changed("currency") {
+		if quoted := cfg.Projects[projects[0]].Currency; quoted != "" {
+			invoiceCurrency = quoted
+		}
+	}
+
+	// Calculate and display result
+	result, err := calc.CalculateInvoice(cmd.Context(), invoiceCurrency, inputs...)
+	if err != nil {
+		return fmt.Errorf("calculation error: %w", err)
+	}
+
+	if err := enforcePolicy(cfg, result, clock); err != nil {
+		return err
+	}
+
+	output, err := calc.Format(result, outputMode)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+
+	if ledgerPath != "" && result.RetainerBank != nil {
+		if err := saveLedger(result.RetainerBank); err != nil {
+			return fmt.Errorf("ledger error: %v", err)
+		}
+	}
+	return nil
+}
+
+// buildClock resolves the current date from --today and --tz
+func buildClock() (billing.Clock, error) {
+	location := time.Local
+	if timezone != "" {
+		loc, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, usageError{fmt.Errorf("invalid time zone: %s", timezone)}
+		}
+		location = loc
+	}
+
+	if todayDate == "" {
+		return calculator.SystemClock{Location: location}, nil
+	}
+	today, err := time.ParseInLocation("2006-01-02", todayDate, location)
+	if err != nil {
+		return nil, usageError{fmt.Errorf("invalid --today date: %s (use YYYY-MM-DD)", todayDate)}
+	}
+	return calculator.FixedClock{Time: today}, nil
+}
+
+// inputFlags holds the values of the time, line item and expense flags
+type inputFlags struct {
+	hours       []float64
+	days        []int
+	weeks       []int
+	months      []string
+	fromDate    string
+	toDate      string
+	toExclusive bool
+	fixedFees   []string
+	milestones  []string
+	recurring   []string
+	expenses    []string
+	expenseFile string
+}
+
+// define defines the input flags on flags, storing their values in f
+func (f *inputFlags) define(flags *pflag.FlagSet) {
+	flags.Float64SliceVarP(&f.hours, "hours", "h", []float64{}, "Add worked hours (can be used multiple times)")
+	flags.IntSliceVarP(&f.days, "days", "d", []int{}, "Add worked days (can be used multiple times)")
+	flags.IntSliceVarP(&f.weeks, "weeks", "s", []int{}, "Add worked weeks (can be used multiple times)")
+	flags.StringSliceVarP(&f.months, "months", "m", []string{}, "Add months or periods (MM, YYYY-MM, YYYY-Qn, YYYY-Wnn, ranges, names; can be used multiple times)")
+	flags.StringVar(&f.fromDate, "from", "", "Bill the workdays from this date (YYYY-MM-DD)")
+	flags.StringVar(&f.toDate, "to", "", "Bill the workdays up to this date (YYYY-MM-DD, inclusive)")
+	flags.BoolVar(&f.toExclusive, "to-exclusive", false, "Do not bill the --to date itself")
+	flags.StringArrayVar(&f.fixedFees, "fixed", []string{}, "Add a fixed fee (DESC:AMOUNT, can be used multiple times)")
+	flags.StringArrayVar(&f.milestones, "milestone", []string{}, "Add a milestone (DESC:PERCENT:TOTAL, can be used multiple times)")
+	flags.StringArrayVar(&f.recurring, "recurring", []string{}, "Add a monthly fee (DESC:AMOUNT, can be used multiple times)")
+	flags.StringArrayVar(&f.expenses, "expense", []string{}, "Add a reimbursable expense (DESC:AMOUNT[:CURRENCY], can be used multiple times)")
+	flags.StringVar(&f.expenseFile, "expenses", "", "Load reimbursable expenses from a CSV file")
+}
+
+// empty reports whether no time, line item or expense was given
+func (f *inputFlags) empty() bool {
+	return len(f.hours) == 0 && len(f.days) == 0 && len(f.weeks) == 0 && len(f.months) == 0 &&
+		f.fromDate == "" && f.toDate == "" &&
+		len(f.fixedFees) == 0 && len(f.milestones) == 0 && len(f.recurring) == 0 &&
+		len(f.expenses) == 0 && f.expenseFile == ""
+}
+
+// input builds the billing input, with the problems of every invalid value
+func (f *inputFlags) input() (billing.Input, []error) {
+	input := billing.Input{
+		Hours:  f.hours,
+		Days:   f.days,
+		Weeks:  f.weeks,
+		Months: f.months,
+	}
+	var problems []error
+	if err := f.checkRange(); err != nil {
+		problems = append(problems, err)
+	} else {
+		input.From, input.To, input.ToExclusive = f.fromDate, f.toDate, f.toExclusive
+	}
+
+	items, itemProblems := f.parseItems()
+	problems = append(problems, itemProblems...)
+	input.Items = items
+
+	expenseInputs, expenseProblems := f.parseExpenses()
+	problems = append(problems, expenseProblems...)
+	input.Expenses = expenseInputs
+	return input, problems
+}
+
+// givenFlag is an input, --project or --person flag as it was given
+type givenFlag struct {
+	name, value string
+}
+
+// givenOrder lists the input, --project and --person flags in the order
+// they were parsed
+var givenOrder []givenFlag
+
+// orderedValue records every value set on a flag in givenOrder
+type orderedValue struct {
+	pflag.Value
+	name string
+}
+
+// Set sets the value and records it
+func (v *orderedValue) Set(value string) error {
+	if err := v.Value.Set(value); err != nil {
+		return err
+	}
+	givenOrder = append(givenOrder, givenFlag{v.name, value})
+	return nil
+}
+
+// orderedSlice is an orderedValue for a list flag
+type orderedSlice struct {
+	*orderedValue
+	slice pflag.SliceValue
+}
+
+// Append appends a value without recording it
+func (v orderedSlice) Append(value string) error {
+	return v.slice.Append(value)
+}
+
+// Replace replaces the values without recording them
+func (v orderedSlice) Replace(values []string) error {
+	return v.slice.Replace(values)
+}
+
+// GetSlice returns the values
+func (v orderedSlice) GetSlice() []string {
+	return v.slice.GetSlice()
+}
+
+// recordOrder records the order of the named flags of flags
+func recordOrder(flags *pflag.FlagSet, names ...string) {
+	for _, name := range names {
+		flag := flags.Lookup(name)
+		ordered := &orderedValue{Value: flag.Value, name: name}
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			flag.Value = orderedSlice{ordered, slice}
+		} else {
+			flag.Value = ordered
+		}
+	}
+}
+
+// taggedInputs splits the input flags among the --project and --person
+// flags given. When only one of them is given, all input is billed to it;
+// otherwise each input is billed to the --project and --person before it,
+// and input given before them to no project or person.
+func taggedInputs() ([]billing.Input, []error) {
+	if len(projects)+len(people) <= 1 {
+		input, problems := given.input()
+		if len(projects) == 1 {
+			input.Project = projects[0]
+		}
+		if len(people) == 1 {
+			input.Person = people[0]
+		}
+		return []billing.Input{input}, problems
+	}
+
+	type segment struct {
+		project, person string
+		values          inputFlags
+		flags           *pflag.FlagSet
+	}
+	var segments []*segment
+	current := &segment{}
+	start := func(project, person string) {
+		current = &segment{project: project, person: person, flags: pflag.NewFlagSet(project, pflag.ContinueOnError)}
+		current.values.define(current.flags)
+		segments = append(segments, current)
+	}
+	start("", "")
+
+	// Replay the flags in order, routing each input flag to its segment
+	for _, flag := range givenOrder {
+		switch flag.name {
+		case "project":
+			start(flag.value, current.person)
+		case "person":
+			start(current.project, flag.value)
+		default:
+			if err := current.flags.Set(flag.name, flag.value); err != nil {
+				return nil, []error{usageError{err}}
+			}
+		}
+	}
+
+	var inputs []billing.Input
+	var problems []error
+	for _, s := range segments {
+		if s.values.empty() {
+			continue
+		}
+		input, inputProblems := s.values.input()
+		input.Project, input.Person = s.project, s.person
+		inputs = append(inputs, input)
+		problems = append(problems, inputProblems...)
+	}
+	return inputs, problems
+}
+
+// checkRange reports a date range given with only one of --from and --to
+func (f *inputFlags) checkRange() error {
+	if (f.fromDate == "") != (f.toDate == "") {
+		return &calculator.InputError{Kind: calculator.ErrInvalidInput, Field: "from", Value: f.fromDate, Err: errors.New("--from and --to must be used together")}
+	}
+	return nil
+}
+
+// parseItems collects the line items given on the command line, with the
+// problems of every invalid one
+func (f *inputFlags) parseItems() ([]billing.Item, []error) {
+	var items []billing.Item
+	var problems []error
+	for _, group := range []struct {
+		itemType string
+		values   []string
+	}{
+		{billing.ItemFixed, f.fixedFees},
+		{billing.ItemMilestone, f.milestones},
+		{billing.ItemRecurring, f.recurring},
+	} {
+		for i, value := range group.values {
+			item, err := billing.ParseItem(group.itemType, value)
+			if err != nil {
+				problems = append(problems, occurrence(err, i))
+				continue
+			}
+			items = append(items, item)
+		}
+	}
+	return items, problems
+}
+
+// parseExpenses collects expenses from flags and the expenses file, with
+// the problems of every invalid one
+func (f *inputFlags) parseExpenses() ([]billing.Expense, []error) {
+	var result []billing.Expense
+	var problems []error
+	if f.expenseFile != "" {
+		file, err := os.Open(f.expenseFile)
+		if err != nil {
+			problems = append(problems, &calculator.InputError{
+				Kind:  calculator.ErrInvalidInput,
+				Field: "expenses",
+				Value: f.expenseFile,
+				Err:   fmt.Errorf("failed to open expenses file: %v", err),
+			})
+		} else {
+			defer file.Close()
+			result, err = billing.ReadExpenses(file)
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: %w", f.expenseFile, err))
+			}
 		}
+	}
 
-		// Prepare input
-		input := calculator.TimeInput{
-			Hours:  hours,
-			Days:   days,
-			Weeks:  weeks,
-			Months: months,
+	for i, value := range f.expenses {
+		expense, err := billing.ParseExpense(value)
+		if err != nil {
+			problems = append(problems, occurrence(err, i))
+			continue
 		}
+		result = append(result, expense)
+	}
+	return result, problems
+}
 
-		// Calculate and display result
-		result, err := calc.Calculate(input, currency)
+// applyExchangeRates adds the --fx rates to the configuration, returning
+// the problems of every invalid one
+func applyExchangeRates(cfg *config.BillingConfig) []error {
+	var problems []error
+	for _, value := range fxRates {
+		pair, rateStr, ok := strings.Cut(value, "=")
+		from, to, okPair := strings.Cut(pair, "/")
+		if !ok || !okPair {
+			problems = append(problems, &config.FieldError{Field: "fx", Value: value, Err: fmt.Errorf("invalid exchange rate: %s (use FROM/TO=RATE)", value)})
+			continue
+		}
+		rate, err := strconv.ParseFloat(rateStr, 64)
 		if err != nil {
-			return fmt.Errorf("calculation error: %v", err)
+			problems = append(problems, &config.FieldError{Field: "fx", Value: value, Err: fmt.Errorf("invalid exchange rate: %s", value)})
+			continue
+		}
+		if err := cfg.SetExchangeRate(from, to, rate); err != nil {
+			problems = append(problems, err)
 		}
+	}
+	return problems
+}
+
+// loadConfig resolves the billing configuration from the config file and
+// selected client profile, falling back to the built-in defaults
+func loadConfig() (*config.BillingConfig, error) {
+	file, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		if client != "" {
+			return nil, &config.FieldError{Field: "profile", Value: client, Err: fmt.Errorf("profile %s requested but no config file found", client)}
+		}
+		slog.Debug("config source", "source", "defaults")
+		return config.NewBillingConfig(), nil
+	}
+
+	activeProfile = client
+	if activeProfile == "" {
+		activeProfile = file.DefaultProfile
+	}
+	slog.Debug("config profile", "profile", activeProfile)
+	return file.Profile(activeProfile)
+}
+
+// loadConfigFile reads the config file from --config, $BILLCTL_CONFIG or the
+// default location. It returns nil when no file exists at the default location.
+func loadConfigFile() (*config.File, error) {
+	path, source := configFilePath()
+	if source == configSourceDefault {
+		if _, err := os.Stat(path); path == "" || errors.Is(err, os.ErrNotExist) {
+			slog.Debug("config file not found", "path", path)
+			return nil, nil
+		}
+	}
+	slog.Debug("config source", "source", source, "path", path)
+	return config.LoadFile(path)
+}
+
+// Where the config file path came from
+const (
+	configSourceFlag    = "--config"
+	configSourceEnv     = "BILLCTL_CONFIG"
+	configSourceDefault = "default location"
+)
 
-		fmt.Print(calc.FormatResult(result))
+// configFilePath returns the path of the config file and where it came from
+func configFilePath() (string, string) {
+	if configPath != "" {
+		return configPath, configSourceFlag
+	}
+	if path := os.Getenv("BILLCTL_CONFIG"); path != "" {
+		return path, configSourceEnv
+	}
+	return config.DefaultPath(), configSourceDefault
+}
+
+// loadPolicy reads the policy file from --policy or $BILLCTL_POLICY. It
+// returns nil when no policy is configured.
+func loadPolicy() (*policy.Policy, error) {
+	path := policyPath
+	if path == "" {
+		path = os.Getenv("BILLCTL_POLICY")
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return policy.Load(path)
+}
+
+// policyCheck returns the check applied by batch and serve, or nil when no
+// policy is configured
+func policyCheck() (func(client string, cfg *config.BillingConfig, result *billing.Result) error, error) {
+	pol, err := loadPolicy()
+	if err != nil || pol == nil {
+		return nil, err
+	}
+	return pol.Check, nil
+}
+
+// enforcePolicy blocks a result that breaks the policy unless the run was
+// overridden with --override-policy, in which case the override is recorded
+func enforcePolicy(cfg *config.BillingConfig, result *billing.Result, clock billing.Clock) error {
+	pol, err := loadPolicy()
+	if err != nil {
+		return fmt.Errorf("policy error: %v", err)
+	}
+	if pol == nil {
 		return nil
-	},
+	}
+
+	violations := pol.Evaluate(activeProfile, cfg, result)
+	if len(violations) == 0 {
+		return nil
+	}
+	if overrideReason == "" {
+		return &policy.ViolationError{Client: activeProfile, Violations: violations}
+	}
+
+	override := policy.Override{
+		Time:       clock.Now(),
+		User:       os.Getenv("USER"),
+		Client:     activeProfile,
+		Reason:     overrideReason,
+		Violations: violations,
+		Total:      result.TotalAmount,
+		Currency:   result.Currency,
+	}
+	if err := pol.RecordOverride(override); err != nil {
+		return fmt.Errorf("policy error: %v", err)
+	}
+	for _, violation := range violations {
+		fmt.Fprintf(os.Stderr, "Advertencia: política ignorada: %s\n", violation.Message)
+	}
+	return nil
+}
+
+// ledgerKey returns the ledger entry used for the selected profile
+func ledgerKey() string {
+	if activeProfile == "" {
+		return "default"
+	}
+	return activeProfile
+}
+
+// readLedger reads all retainer balances stored in the ledger file
+func readLedger() (map[string]*billing.RetainerBank, error) {
+	ledger := map[string]*billing.RetainerBank{}
+	data, err := os.ReadFile(ledgerPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return ledger, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &ledger); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %v", ledgerPath, err)
+	}
+	return ledger, nil
+}
+
+// loadLedger returns the retainer balance of the selected profile, or nil
+// when none was stored
+func loadLedger() (*billing.RetainerBank, error) {
+	ledger, err := readLedger()
+	if err != nil {
+		return nil, err
+	}
+	return ledger[ledgerKey()], nil
+}
+
+// saveLedger stores the retainer balance of the selected profile
+func saveLedger(bank *billing.RetainerBank) error {
+	ledger, err := readLedger()
+	if err != nil {
+		return err
+	}
+	ledger[ledgerKey()] = bank
+
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(ledgerPath, append(data, '\n'), 0o644)
 }
 
 func init() {
 	// Disable default help command to avoid conflict with -h for hours
 	rootCmd.SetHelpCommand(&cobra.Command{Hidden: true})
+	rootCmd.CompletionOptions.DisableDefaultCmd = true
+
+	// main reports errors once, with an exit code per error class
+	rootCmd.SilenceErrors = true
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		silenceUsageForJSON(cmd)
+		return usageError{err}
+	})
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		silenceUsageForJSON(cmd)
+		return setupLogging(cmd, os.Stderr)
+	}
+	rootCmd.AddCommand(calcCmd, ratesCmd, monthCmd, configCmd, versionCmd,
+		batchCmd, interactiveCmd, serveCmd, grpcCmd, completionCmd, genDocsCmd)
 
 	// Define flags
-	rootCmd.Flags().IntSliceVarP(&hours, "hours", "h", []int{}, "Add worked hours (can be used multiple times)")
-	rootCmd.Flags().IntSliceVarP(&days, "days", "d", []int{}, "Add worked days (can be used multiple times)")
-	rootCmd.Flags().IntSliceVarP(&weeks, "weeks", "s", []int{}, "Add worked weeks (can be used multiple times)")
-	rootCmd.Flags().StringSliceVarP(&months, "months", "m", []string{}, "Add specific months (MM or YYYY-MM format, can be used multiple times)")
-	rootCmd.Flags().StringVar(&currency, "currency", "U$S", "Set currency (default: U$S)")
-	rootCmd.Flags().BoolVar(&showRates, "rates", false, "Show rate table")
-	rootCmd.Flags().BoolVar(&showVersion, "version", false, "Show version information")
+	addCalcFlags(rootCmd)
+	rootCmd.Flags().BoolVar(&showRates, "rates", false, "Show rate table (same as the rates command)")
+	rootCmd.Flags().BoolVar(&showVersion, "version", false, "Show version information (same as the version command)")
+	rootCmd.PersistentFlags().StringVarP(&outputMode, "output", "o", calculator.OutputText, "Output format: text, json or csv")
+	rootCmd.PersistentFlags().StringVar(&todayDate, "today", "", "Use this date (YYYY-MM-DD) as the current date")
+	rootCmd.PersistentFlags().StringVar(&timezone, "tz", "", "Time zone used to resolve the current date (default: local)")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Path to a JSON config file with billing profiles")
+	rootCmd.PersistentFlags().StringVar(&policyPath, "policy", "", "Path to a JSON policy file checked before any output")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "warn", "Log level: debug, info, warn or error")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", logFormatText, "Log format: text or json")
+	rootCmd.PersistentFlags().BoolVar(&explain, "explain", false, "Trace every calculation step to stderr (same as --log-level debug)")
+
+	rootCmd.RegisterFlagCompletionFunc("output", completeNames(calculator.OutputText, calculator.OutputJSON, calculator.OutputCSV))
+	rootCmd.RegisterFlagCompletionFunc("log-level", completeNames("debug", "info", "warn", "error"))
+	rootCmd.RegisterFlagCompletionFunc("log-format", completeNames(logFormatText, logFormatJSON))
+}
+
+// addCalcFlags defines the flags of a calculation
+func addCalcFlags(cmd *cobra.Command) {
+	addInputFlags(cmd)
+	addProfileFlags(cmd)
+	addCurrencyFlag(cmd)
+	cmd.Flags().StringVar(&overrideReason, "override-policy", "", "Print despite policy violations, recording this reason")
+	cmd.Flags().StringVar(&ledgerPath, "ledger", "", "JSON file tracking the retainer hour bank across runs")
 
-	// Add manual help flag to replace the disabled default one
-	rootCmd.Flags().BoolP("help", "?", false, "Show help message")
+	// Add manual help flag to replace the default one, whose -h is taken by --hours
+	cmd.Flags().BoolP("help", "?", false, "Show help message")
+}
 
-	// Add flag aliases for backward compatibility
-	rootCmd.Flags().Lookup("currency").NoOptDefVal = "U$S"
+// addInputFlags defines the time, line item and expense flags
+func addInputFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	given.define(flags)
+	flags.StringArrayVar(&projects, "project", []string{}, "Bill the input to a project of the profile (input after each --project goes to it)")
+	flags.StringArrayVar(&people, "person", []string{}, "Bill the input as work of a team member (input after each --person goes to them)")
+	recordOrder(flags, "hours", "days", "weeks", "months", "from", "to", "to-exclusive",
+		"fixed", "milestone", "recurring", "expense", "expenses", "project", "person")
 
 	// Set flag usage messages
-	rootCmd.Flags().SetAnnotation("hours", "help", []string{"Specify additional hours worked"})
-	rootCmd.Flags().SetAnnotation("days", "help", []string{"Specify additional days worked"})
-	rootCmd.Flags().SetAnnotation("weeks", "help", []string{"Specify additional weeks worked"})
-	rootCmd.Flags().SetAnnotation("months", "help", []string{"Specify months in MM or YYYY-MM format"})
+	flags.SetAnnotation("hours", "help", []string{"Specify additional hours worked"})
+	flags.SetAnnotation("days", "help", []string{"Specify additional days worked"})
+	flags.SetAnnotation("weeks", "help", []string{"Specify additional weeks worked"})
+	flags.SetAnnotation("months", "help", []string{"Specify months in MM or YYYY-MM format"})
+
+	cmd.RegisterFlagCompletionFunc("months", completeMonths)
+	cmd.RegisterFlagCompletionFunc("project", completeProjects)
+	cmd.RegisterFlagCompletionFunc("person", completeTeam)
+}
+
+// addProfileFlags defines the flags selecting and adjusting the billing profile
+func addProfileFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&client, "client", "", "Billing profile to use from the config file")
+	cmd.Flags().StringVar(&rate, "rate", "", "Set the contract rate as AMOUNT/UNIT (units: h, d, w, m, y)")
+	cmd.Flags().StringVar(&weeksMode, "weeks-per-month", "", "Weeks per month strategy: fixed, average, calendar or working")
+	cmd.Flags().StringArrayVar(&fxRates, "fx", []string{}, "Set an exchange rate (FROM/TO=RATE, can be used multiple times)")
+
+	cmd.RegisterFlagCompletionFunc("client", completeClients)
+	cmd.RegisterFlagCompletionFunc("weeks-per-month", completeNames(config.WeeksFixed, config.WeeksAverage, config.WeeksCalendar, config.WeeksWorking))
+}
+
+// addCurrencyFlag defines --currency
+func addCurrencyFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&currency, "currency", "U$S", "Set currency (default: U$S)")
+	cmd.RegisterFlagCompletionFunc("currency", completeCurrencies)
 }
 
 func main() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(reportError(os.Stderr, err))
 	}
 }
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+	"github.com/develpudu/billctl/internal/policy"
+	"github.com/develpudu/billctl/internal/server"
+	"github.com/develpudu/billctl/pkg/billing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// execute runs billctl with args and returns what it printed on stdout
+func execute(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BILLCTL_CONFIG", "")
+	t.Setenv("BILLCTL_POLICY", "")
+	resetFlags(rootCmd)
+	givenOrder = nil
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe() unexpected error: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	defer func() { os.Stdout = stdout }()
+
+	rootCmd.SetArgs(args)
+	runErr := rootCmd.Execute()
+	writer.Close()
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("reading stdout: %v", err)
+	}
+	return string(output), runErr
+}
+
+// resetFlags restores the defaults of every flag, so each execution starts
+// from a clean slate
+func resetFlags(cmd *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if slice, ok := flag.Value.(pflag.SliceValue); ok {
+			slice.Replace(nil)
+		} else {
+			flag.Value.Set(flag.DefValue)
+		}
+		flag.Changed = false
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
+
+func TestDocumentedCurrencyExamples(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"calc", "-d", "15", "--currency", "EUR", "--today", "2026-10-18"}, "TOTAL A FACTURAR: EUR"},
+		{[]string{"-d", "15", "--currency", "EUR", "--today", "2026-10-18"}, "TOTAL A FACTURAR: EUR"},
+		{[]string{"-m", "03", "--currency", "USD", "--today", "2026-10-18"}, "TOTAL A FACTURAR: USD"},
+		{[]string{"rates", "--currency", "EUR", "--today", "2026-10-18"}, "EUR"},
+		{[]string{"calc", "-d", "15", "--currency=EUR", "--today", "2026-10-18"}, "TOTAL A FACTURAR: EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			output, err := execute(t, tt.args...)
+			if err != nil {
+				t.Fatalf("billctl %s unexpected error: %v", strings.Join(tt.args, " "), err)
+			}
+			if !strings.Contains(output, tt.expected) {
+				t.Errorf("billctl %s output missing %q:\n%s", strings.Join(tt.args, " "), tt.expected, output)
+			}
+		})
+	}
+}
+
+func TestTaggedInputsFollowGivenArgs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	profiles := `{"profiles": {"acme": {"rate_anchor": "hourly", "rate_amount": 40,
+		"projects": {"web": {"hourly_rate": 50}},
+		"team": {"ana": {"rate_anchor": "daily", "rate_amount": 480}, "luis": {"rate_amount": 30}}}}}`
+	if err := os.WriteFile(path, []byte(profiles), 0o644); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+
+	output, err := execute(t, "calc", "--config", path, "--client", "acme", "-o", "json",
+		"--person", "ana", "-d", "10", "--person", "luis", "-h", "32", "--project", "web", "-h", "2")
+	if err != nil {
+		t.Fatalf("billctl calc unexpected error: %v", err)
+	}
+	var result struct {
+		TotalAmount float64 `json:"total_amount"`
+		People      []struct {
+			Person    string  `json:"person"`
+			TotalTime float64 `json:"total_time"`
+		} `json:"people"`
+		Projects []struct {
+			Project   string  `json:"project"`
+			TotalTime float64 `json:"total_time"`
+		} `json:"projects"`
+	}
+	if err := json.Unmarshal([]byte(output), &result); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, output)
+	}
+
+	// luis keeps working after --project: 32 h at 30 plus 2 h at the web rate
+	if len(result.People) != 2 || result.People[0].TotalTime != 80 || result.People[1].TotalTime != 34 {
+		t.Errorf("people = %+v, want ana 80 h and luis 34 h", result.People)
+	}
+	if len(result.Projects) != 2 || result.Projects[1].Project != "web" || result.Projects[1].TotalTime != 2 {
+		t.Errorf("projects = %+v, want 2 h on web", result.Projects)
+	}
+	if result.TotalAmount != 4800+960+100 {
+		t.Errorf("total_amount = %.2f, want %.2f", result.TotalAmount, 4800.0+960+100)
+	}
+}
+
+func TestPolicyChecksBilledRates(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.json")
+	profiles := `{"profiles": {"acme": {"rate_anchor": "hourly", "rate_amount": 40,
+		"projects": {"maint": {"hourly_rate": 15}}}}}`
+	if err := os.WriteFile(path, []byte(profiles), 0o644); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+	policyFile := filepath.Join(dir, "policy.json")
+	if err := os.WriteFile(policyFile, []byte(`{"min_hourly_rate": 18}`), 0o644); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+
+	_, err := execute(t, "calc", "--config", path, "--client", "acme", "--policy", policyFile,
+		"--project", "maint", "-h", "2", "--today", "2026-10-18")
+	var violationErr *policy.ViolationError
+	if !errors.As(err, &violationErr) || len(violationErr.Violations) != 1 ||
+		!strings.Contains(violationErr.Violations[0].Message, "hourly rate of project maint") {
+		t.Fatalf("billctl calc error = %v, want a violation of the maint rate", err)
+	}
+
+	if _, err := execute(t, "calc", "--config", path, "--client", "acme", "--policy", policyFile,
+		"-h", "2", "--today", "2026-10-18"); err != nil {
+		t.Errorf("billctl calc unexpected error: %v", err)
+	}
+}
+
+func TestProfileResolverWithoutConfigFile(t *testing.T) {
+	resolve := profileResolver(nil)
+	if _, err := resolve(""); err != nil {
+		t.Fatalf("profileResolver(nil)(\"\") unexpected error: %v", err)
+	}
+	if _, err := resolve("acme"); !errors.Is(err, config.ErrUnknownProfile) {
+		t.Fatalf("profileResolver(nil)(acme) error = %v, want ErrUnknownProfile", err)
+	}
+
+	api := httptest.NewServer((&server.Server{Profile: resolve, Clock: calculator.SystemClock{}}).Handler())
+	defer api.Close()
+	resp, err := http.Get(api.URL + "/v1/rates?profile=acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("GET /v1/rates?profile=acme status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestRatesJSONMatchesLibrary(t *testing.T) {
+	output, err := execute(t, "rates", "--rate", "25/h", "--currency", "EUR", "-o", "json", "--today", "2026-10-18")
+	if err != nil {
+		t.Fatalf("billctl rates unexpected error: %v", err)
+	}
+	var rates billing.Rates
+	decoder := json.NewDecoder(strings.NewReader(output))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rates); err != nil {
+		t.Fatalf("billctl rates -o json is not a billing.Rates: %v\n%s", err, output)
+	}
+	if rates.Currency != "EUR" || rates.Hourly != 25 || rates.Daily != 200 {
+		t.Errorf("billctl rates -o json = %+v, want EUR 25/h and 200/d", rates)
+	}
+}
+
+func TestBatchExitCode(t *testing.T) {
+	tests := []struct {
+		name             string
+		jobs             string
+		expectedExitCode int
+	}{
+		{"invalid input", "period,hours\n,10\n2024-13,\n,-1\n", exitInput},
+		{"unknown profile", "client,hours\n,10\nnobody,5\n", exitConfig},
+		{"mixed classes", "client,period,hours\n,,10\n,2024-13,\nnobody,,5\n", exitError},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "jobs.csv")
+			if err := os.WriteFile(path, []byte(test.jobs), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := execute(t, "batch", path)
+			if err == nil {
+				t.Fatal("billctl batch expected error, got nil")
+			}
+			if detail := describeError(err); detail.Code != "batch_failed" || detail.ExitCode != test.expectedExitCode {
+				t.Errorf("billctl batch error = %+v, want batch_failed with exit code %d", detail, test.expectedExitCode)
+			}
+		})
+	}
+}
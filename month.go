@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/pkg/billing"
+
+	"github.com/spf13/cobra"
+)
+
+var monthCmd = &cobra.Command{
+	Use:   "month MONTH...",
+	Short: "Show the hours and amount of whole months",
+	Long: `Show the days, hours and amount billed for whole months, in MM or
+YYYY-MM format. MM months resolve against the current year (see --today).
+
+Examples:
+  billctl month 2024-02
+  billctl month 01 02 03 --client acme
+  billctl month 2026-09 -o csv`,
+	Args: usageArgs(cobra.MinimumNArgs(1)),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, problems, err := resolveConfig()
+		if err != nil {
+			return err
+		}
+		clock, err := buildClock()
+		if err != nil {
+			return err
+		}
+		calc, err := newBilling(cfg, clock, problems)
+		if err != nil {
+			return err
+		}
+
+		var summaries []billing.Month
+		for i, arg := range args {
+			month, err := calc.Month(cmd.Context(), arg)
+			if err != nil {
+				problems = append(problems, occurrence(err, i))
+				continue
+			}
+			summaries = append(summaries, month)
+		}
+		if len(problems) > 0 {
+			return &calculator.ValidationError{Errors: problems}
+		}
+
+		output, err := calc.FormatMonths(summaries, currency, outputMode)
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+		return nil
+	},
+	ValidArgsFunction: completeMonths,
+}
+
+func init() {
+	addProfileFlags(monthCmd)
+	addCurrencyFlag(monthCmd)
+}
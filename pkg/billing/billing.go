@@ -0,0 +1,164 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// Calculator calculates billing amounts for one configuration. It is safe
+// for concurrent use.
+type Calculator struct {
+	config   *config.BillingConfig
+	clock    Clock
+	logger   *slog.Logger
+	retainer *calculator.RetainerState
+}
+
+// New creates a calculator with the billctl defaults adjusted by the given
+// options, applied in order
+func New(opts ...Option) (*Calculator, error) {
+	c := &Calculator{config: config.NewBillingConfig(), clock: calculator.SystemClock{}}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, configError(err)
+		}
+	}
+	if err := c.config.Validate(); err != nil {
+		return nil, configError(err)
+	}
+	return c, nil
+}
+
+// Calculate bills the input. It returns the context's error when ctx is
+// done before the calculation finishes.
+func (c *Calculator) Calculate(ctx context.Context, in Input) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	input, err := toTimeInput(in)
+	if err != nil {
+		return nil, inputError(err)
+	}
+
+	calc := c.engine()
+	result, err := calc.Calculate(input, c.currency(in.Currency))
+	if err != nil {
+		return nil, calculationError(err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.result(calc, result), nil
+}
+
+// CalculateInvoice bills several inputs on one invoice in the given
+// currency, or in the calculator's currency when it is empty. Each input is
+// billed at the rate and schedule of its Project and Person; the result
+// adds up the inputs per project, team member and both, and applies the
+// invoice-wide pricing rules once. The currency of each input is ignored.
+func (c *Calculator) CalculateInvoice(ctx context.Context, currency string, inputs ...Input) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	var timeInputs []calculator.TimeInput
+	for _, in := range inputs {
+		input, err := toTimeInput(in)
+		if err != nil {
+			return nil, inputError(err)
+		}
+		timeInputs = append(timeInputs, input)
+	}
+
+	calc := c.engine()
+	result, err := calc.CalculateProjects(timeInputs, c.currency(currency))
+	if err != nil {
+		return nil, calculationError(err)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.result(calc, result), nil
+}
+
+// Validate checks the inputs without billing them. Unlike Calculate, it
+// reports every problem found instead of stopping at the first one.
+func (c *Calculator) Validate(inputs ...Input) error {
+	calc := c.engine()
+	var problems []error
+	for _, in := range inputs {
+		input, err := toTimeInput(in)
+		if err != nil {
+			problems = append(problems, err)
+		}
+		var validationErr *calculator.ValidationError
+		if err := calc.ValidateInput(input); errors.As(err, &validationErr) {
+			problems = append(problems, validationErr.Errors...)
+		}
+	}
+	if len(problems) > 0 {
+		return inputError(&calculator.ValidationError{Errors: problems})
+	}
+	return nil
+}
+
+// Month returns the hours and amount billed for a whole month, given as MM
+// (a month of the current year) or YYYY-MM
+func (c *Calculator) Month(ctx context.Context, month string) (Month, error) {
+	if err := ctx.Err(); err != nil {
+		return Month{}, err
+	}
+
+	monthInfo, err := c.engine().MonthSummary(month)
+	if err != nil {
+		return Month{}, inputError(err)
+	}
+	return toMonth(monthInfo), nil
+}
+
+// Rates returns the rate table in the given currency, or in the
+// calculator's currency when it is empty
+func (c *Calculator) Rates(currency string) Rates {
+	calc := c.engine()
+	rates := calc.CalculateQuickRates(currency)
+	return Rates{
+		Currency:   c.currency(currency),
+		Hourly:     rates["hourly"],
+		Daily:      rates["daily"],
+		Weekly:     rates["weekly"],
+		Monthly:    rates["monthly"],
+		Annual:     rates["annual"],
+		Indexation: toIndexation(calc.IndexAdjustment()),
+	}
+}
+
+// engine builds the internal calculator for a single call
+func (c *Calculator) engine() *calculator.Calculator {
+	calc := calculator.NewCalculator(c.config)
+	calc.SetClock(c.clock)
+	calc.SetLogger(c.logger)
+	calc.SetRetainerState(c.retainer) // checked by WithRetainerBank
+	return calc
+}
+
+// result converts a calculation result, with the retainer bank left by calc
+func (c *Calculator) result(calc *calculator.Calculator, result *calculator.CalculationResult) *Result {
+	r := toResult(result)
+	if result.Retainer != nil {
+		r.RetainerBank = toRetainerBank(calc.RetainerState())
+	}
+	return r
+}
+
+// currency returns the requested currency or the configured default
+func (c *Calculator) currency(currency string) string {
+	if currency != "" {
+		return currency
+	}
+	return c.config.DefaultCurrency
+}
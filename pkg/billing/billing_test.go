@@ -0,0 +1,472 @@
+package billing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+)
+
+func TestNewOptions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"default_profile": "acme", "profiles": {"acme": {"rate_anchor": "hourly", "rate_amount": 30, "default_currency": "EUR"}}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name             string
+		opts             []Option
+		expectedRate     float64
+		expectedTotal    float64
+		expectedCurrency string
+	}{
+		{"defaults", nil, 13.75, 137.5, "U$S"},
+		{"rate", []Option{WithRate(20, Hourly)}, 20, 200, "U$S"},
+		{"profile", []Option{WithProfile(path, "")}, 30, 300, "EUR"},
+		{"profile then currency", []Option{WithProfile(path, "acme"), WithCurrency("USD")}, 30, 300, "USD"},
+		{"schedule", []Option{WithRate(4000, Monthly), WithSchedule(25, 5)}, 40, 400, "U$S"},
+		{"taxes", []Option{WithRate(20, Hourly), WithTaxRate(21, 0)}, 20, 242, "U$S"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calc, err := New(tt.opts...)
+			if err != nil {
+				t.Fatalf("New() unexpected error: %v", err)
+			}
+			result, err := calc.Calculate(context.Background(), Input{Hours: []float64{10}})
+			if err != nil {
+				t.Fatalf("Calculate() unexpected error: %v", err)
+			}
+			if result.HourlyRate != tt.expectedRate {
+				t.Errorf("Calculate() HourlyRate = %v, want %v", result.HourlyRate, tt.expectedRate)
+			}
+			if result.TotalAmount != tt.expectedTotal {
+				t.Errorf("Calculate() TotalAmount = %v, want %v", result.TotalAmount, tt.expectedTotal)
+			}
+			if result.Currency != tt.expectedCurrency {
+				t.Errorf("Calculate() Currency = %v, want %v", result.Currency, tt.expectedCurrency)
+			}
+		})
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{"negative rate", []Option{WithRate(-1, Hourly)}},
+		{"unknown unit", []Option{WithRate(10, "fortnightly")}},
+		{"unknown strategy", []Option{WithWeeksStrategy("lunar")}},
+		{"empty currency", []Option{WithCurrency("")}},
+		{"nil clock", []Option{WithClock(nil)}},
+		{"missing profile file", []Option{WithProfile(filepath.Join(t.TempDir(), "none.json"), "")}},
+		{"invalid exchange rate", []Option{WithExchangeRate("EUR", "USD", 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.opts...)
+			if !errors.Is(err, ErrInvalidConfig) {
+				t.Fatalf("New() error = %v, want ErrInvalidConfig", err)
+			}
+			var billingErr *Error
+			if !errors.As(err, &billingErr) || billingErr.Err == nil {
+				t.Errorf("New() error = %#v, want a *Error with a cause", err)
+			}
+		})
+	}
+}
+
+func TestCalculateErrors(t *testing.T) {
+	calc, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		input Input
+	}{
+		{"negative hours", Input{Hours: []float64{-1}}},
+		{"invalid month", Input{Months: []string{"2024-13"}}},
+		{"incomplete range", Input{From: "2026-09-01"}},
+		{"unknown item", Input{Items: []Item{{Type: "bonus", Description: "x", Amount: 1}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := calc.Calculate(context.Background(), tt.input)
+			if !errors.Is(err, ErrInvalidInput) {
+				t.Errorf("Calculate() error = %v, want ErrInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestCalculateContext(t *testing.T) {
+	calc, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := calc.Calculate(ctx, Input{Hours: []float64{1}}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Calculate() error = %v, want context.Canceled", err)
+	}
+	if _, err := calc.Month(ctx, "2024-02"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Month() error = %v, want context.Canceled", err)
+	}
+}
+
+func TestCalculateExpenses(t *testing.T) {
+	calc, err := New(WithCurrency("USD"), WithExchangeRate("EUR", "USD", 1.1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	markup := 10.0
+	result, err := calc.Calculate(context.Background(), Input{
+		Expenses: []Expense{{Description: "Flight", Amount: 100, Currency: "EUR", Markup: &markup}},
+	})
+	if err != nil {
+		t.Fatalf("Calculate() unexpected error: %v", err)
+	}
+	if len(result.Expenses) != 1 || math.Abs(result.ExpenseTotal-121) > 1e-9 {
+		t.Errorf("Calculate() expenses = %+v, total %v, want one expense totalling 121", result.Expenses, result.ExpenseTotal)
+	}
+}
+
+func TestCalculateMissingExchangeRate(t *testing.T) {
+	calc, err := New(WithCurrency("USD"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = calc.Calculate(context.Background(), Input{
+		Expenses: []Expense{{Description: "Flight", Amount: 100, Currency: "GBP"}},
+	})
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("Calculate() error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	calc, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := calc.Validate(Input{Hours: []float64{1}}, Input{Months: []string{"2026-09"}}); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+
+	err = calc.Validate(
+		Input{Hours: []float64{-1}, From: "2026-09-01", To: "someday"},
+		Input{Months: []string{"2024-13"}},
+	)
+	if !errors.Is(err, ErrInvalidInput) || !errors.Is(err, ErrNegativeQuantity) || !errors.Is(err, ErrInvalidMonth) {
+		t.Fatalf("Validate() error = %v, want ErrInvalidInput with every problem", err)
+	}
+	var validationErr *calculator.ValidationError
+	if !errors.As(err, &validationErr) || len(validationErr.Errors) != 3 {
+		t.Errorf("Validate() error = %v, want 3 problems", err)
+	}
+}
+
+// The JSON of a Result must match the CLI's --output json
+func TestResultJSON(t *testing.T) {
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	calc, err := New(WithToday(today))
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := Input{
+		Months: []string{"last-month"},
+		Hours:  []float64{4},
+		From:   "2026-10-05",
+		To:     "2026-10-09",
+		Items:  []Item{{Type: ItemFixed, Description: "Setup", Amount: 500}},
+	}
+
+	result, err := calc.Calculate(context.Background(), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	engine := calculator.NewCalculator(config.NewBillingConfig())
+	engine.SetClock(calculator.FixedClock{Time: today})
+	timeInput, err := toTimeInput(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := engine.Calculate(timeInput, "U$S")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(got) != string(want) {
+		t.Errorf("json.Marshal(Result) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// writeProfile writes a config file with the acme profile and returns its path
+func writeProfile(t *testing.T, profile string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"profiles": {"acme": `+profile+`}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// The mapping of an invoice must keep every field of the engine's result,
+// in both directions
+func TestCalculateInvoiceMapping(t *testing.T) {
+	path := writeProfile(t, `{"rate_anchor": "hourly", "rate_amount": 40,
+		"retainer": {"bank_hours": 5, "refill": "monthly", "overage_rate": 25},
+		"pricing": {"total_minimum": 10},
+		"projects": {"web": {"hourly_rate": 50}},
+		"team": {"ana": {"role": "Lead", "hourly_rate": 60}, "luis": {"hourly_rate": 30}}}`)
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	calc, err := New(WithProfile(path, "acme"), WithToday(today))
+	if err != nil {
+		t.Fatal(err)
+	}
+	inputs := []Input{
+		{Person: "ana", Hours: []float64{2}, Expenses: []Expense{{Description: "Taxi", Amount: 10}}},
+		{Person: "luis", Project: "web", Hours: []float64{1}, Items: []Item{{Type: ItemFixed, Description: "Setup", Amount: 100}}},
+	}
+
+	result, err := calc.CalculateInvoice(context.Background(), "EUR", inputs...)
+	if err != nil {
+		t.Fatalf("CalculateInvoice() unexpected error: %v", err)
+	}
+	if len(result.Projects) != 2 || len(result.People) != 2 || len(result.Parts) != 2 || result.Retainer == nil {
+		t.Fatalf("CalculateInvoice() = %+v, want 2 projects, people and parts and a retainer", result)
+	}
+
+	file, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := file.Profile("acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := calculator.NewCalculator(cfg)
+	engine.SetClock(calculator.FixedClock{Time: today})
+	var timeInputs []calculator.TimeInput
+	for _, in := range inputs {
+		input, err := toTimeInput(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		timeInputs = append(timeInputs, input)
+	}
+	expected, err := engine.CalculateProjects(timeInputs, "EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("json.Marshal(Result) =\n%s\nwant\n%s", got, want)
+	}
+
+	for _, format := range []string{FormatText, FormatJSON, FormatCSV} {
+		output, err := calc.Format(result, format)
+		if err != nil {
+			t.Fatalf("Format(%s) unexpected error: %v", format, err)
+		}
+		expectedOutput, err := engine.Format(expected, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output != expectedOutput {
+			t.Errorf("Format(%s) =\n%s\nwant\n%s", format, output, expectedOutput)
+		}
+	}
+
+	if _, err := calc.CalculateInvoice(context.Background(), "", Input{Project: "mobile", Hours: []float64{1}}); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("CalculateInvoice() error = %v, want ErrInvalidConfig for an unknown project", err)
+	}
+}
+
+func TestRatesIndexation(t *testing.T) {
+	path := writeProfile(t, `{"rate_anchor": "hourly", "rate_amount": 40,
+		"indexation": {"base": "2026-01", "percent": 3, "period_months": 3}}`)
+	calc, err := New(WithProfile(path, "acme"), WithToday(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rates := calc.Rates("")
+	if rates.Indexation == nil || rates.Indexation.Periods != 2 || math.Abs(rates.Indexation.Factor-1.0609) > 1e-9 {
+		t.Fatalf("Rates().Indexation = %+v, want 2 periods with factor 1.0609", rates.Indexation)
+	}
+	if math.Abs(rates.Hourly-40*1.0609) > 1e-9 {
+		t.Errorf("Rates().Hourly = %v, want %v", rates.Hourly, 40*1.0609)
+	}
+
+	calc, err = New(WithRate(40, Hourly))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rates := calc.Rates(""); rates.Indexation != nil {
+		t.Errorf("Rates().Indexation = %+v, want nil without indexation", rates.Indexation)
+	}
+}
+
+func TestWithConfig(t *testing.T) {
+	cfg := config.NewBillingConfig()
+	if err := cfg.SetRate(config.AnchorHourly, 20); err != nil {
+		t.Fatal(err)
+	}
+	calc, err := New(WithConfig(cfg), WithCurrency("EUR"))
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	result, err := calc.Calculate(context.Background(), Input{Hours: []float64{10}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.TotalAmount != 200 || result.Currency != "EUR" {
+		t.Errorf("Calculate() = %v %v, want EUR 200", result.Currency, result.TotalAmount)
+	}
+
+	if _, err := New(WithConfig(nil)); !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("New() error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestWithRetainerBank(t *testing.T) {
+	path := writeProfile(t, `{"rate_anchor": "hourly", "rate_amount": 40,
+		"retainer": {"bank_hours": 10, "refill": "monthly", "overage_rate": 25}}`)
+	today := WithToday(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+	calc, err := New(WithProfile(path, "acme"), today)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := calc.Calculate(context.Background(), Input{Hours: []float64{6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.RetainerBank == nil || first.Retainer.RemainingHours != 4 {
+		t.Fatalf("Calculate() retainer = %+v, bank %+v, want 4 hours left", first.Retainer, first.RetainerBank)
+	}
+
+	// A calculator without the bank starts full again
+	again, err := calc.Calculate(context.Background(), Input{Hours: []float64{6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.Retainer.OverageHours != 0 {
+		t.Errorf("Calculate() overage = %v, want 0 from a full bank", again.Retainer.OverageHours)
+	}
+
+	carried, err := New(WithProfile(path, "acme"), today, WithRetainerBank(first.RetainerBank))
+	if err != nil {
+		t.Fatalf("New() unexpected error: %v", err)
+	}
+	second, err := carried.Calculate(context.Background(), Input{Hours: []float64{6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.Retainer.DrawnHours != 4 || second.Retainer.OverageHours != 2 {
+		t.Errorf("Calculate() retainer = %+v, want 4 hours drawn and 2 of overage", second.Retainer)
+	}
+
+	_, err = New(WithRetainerBank(&RetainerBank{Period: "October"}))
+	if !errors.Is(err, ErrInvalidConfig) {
+		t.Errorf("New() error = %v, want ErrInvalidConfig", err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	item, err := ParseItem(ItemMilestone, "Beta:30:1000")
+	if err != nil || item != (Item{Type: ItemMilestone, Description: "Beta", Amount: 1000, Percent: 30}) {
+		t.Errorf("ParseItem() = %+v, %v, want the Beta milestone", item, err)
+	}
+	if _, err := ParseItem(ItemFixed, "bad"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("ParseItem() error = %v, want ErrInvalidInput", err)
+	}
+
+	expense, err := ParseExpense("Taxi:30:EUR")
+	if err != nil || expense.Description != "Taxi" || expense.Amount != 30 || expense.Currency != "EUR" {
+		t.Errorf("ParseExpense() = %+v, %v, want Taxi 30 EUR", expense, err)
+	}
+	if _, err := ParseExpense("Taxi:-1"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("ParseExpense() error = %v, want ErrInvalidInput", err)
+	}
+
+	expenses, err := ReadExpenses(strings.NewReader("description,amount,currency\nHotel,120,USD\nTaxi,15,\n"))
+	if err != nil || len(expenses) != 2 || expenses[0].Currency != "USD" {
+		t.Errorf("ReadExpenses() = %+v, %v, want 2 expenses", expenses, err)
+	}
+	if _, err := ReadExpenses(strings.NewReader("amount\n1\n")); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("ReadExpenses() error = %v, want ErrInvalidInput", err)
+	}
+}
+
+func TestFormat(t *testing.T) {
+	calc, err := New(WithRate(25, Hourly), WithCurrency("EUR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := calc.Calculate(context.Background(), Input{Hours: []float64{4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	month, err := calc.Month(context.Background(), "2024-02")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{FormatText, FormatJSON, FormatCSV} {
+		output, err := calc.Format(result, format)
+		if err != nil || !strings.Contains(output, "100") {
+			t.Errorf("Format(%s) = %q, %v, want the 100 total", format, output, err)
+		}
+		output, err = calc.FormatRates("", format)
+		if err != nil || !strings.Contains(output, "200") {
+			t.Errorf("FormatRates(%s) = %q, %v, want the 200 daily rate", format, output, err)
+		}
+		output, err = calc.FormatMonths([]Month{month}, "", format)
+		if err != nil || !strings.Contains(output, "5800") {
+			t.Errorf("FormatMonths(%s) = %q, %v, want the 5800 month", format, output, err)
+		}
+	}
+
+	if _, err := calc.Format(result, "xml"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("Format() error = %v, want ErrInvalidInput", err)
+	}
+	if _, err := calc.FormatRates("", "xml"); !errors.Is(err, ErrInvalidInput) {
+		t.Errorf("FormatRates() error = %v, want ErrInvalidInput", err)
+	}
+}
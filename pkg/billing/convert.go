@@ -0,0 +1,381 @@
+package billing
+
+import (
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// The public types are mapped field by field, so the engine's types can
+// change without changing the API.
+
+// toTimeInput converts the input into calculator input
+func toTimeInput(in Input) (calculator.TimeInput, error) {
+	input := calculator.TimeInput{
+		Hours:   in.Hours,
+		Days:    in.Days,
+		Weeks:   in.Weeks,
+		Months:  in.Months,
+		Project: in.Project,
+		Person:  in.Person,
+	}
+	for _, item := range in.Items {
+		input.Items = append(input.Items, calculator.ItemInput{
+			Type:        item.Type,
+			Description: item.Description,
+			Amount:      item.Amount,
+			Percent:     item.Percent,
+		})
+	}
+	for _, expense := range in.Expenses {
+		input.Expenses = append(input.Expenses, calculator.ExpenseInput{
+			Description: expense.Description,
+			Amount:      expense.Amount,
+			Currency:    expense.Currency,
+			Markup:      expense.Markup,
+			Receipt:     expense.Receipt,
+		})
+	}
+
+	if in.From != "" || in.To != "" {
+		dateRange, err := calculator.ParseDateRange(in.From, in.To, in.ToExclusive)
+		if err != nil {
+			return input, err
+		}
+		input.Ranges = []calculator.DateRange{dateRange}
+	}
+	return input, nil
+}
+
+// toItem converts a line item
+func toItem(item calculator.ItemInput) Item {
+	return Item{
+		Type:        item.Type,
+		Description: item.Description,
+		Amount:      item.Amount,
+		Percent:     item.Percent,
+	}
+}
+
+// toExpense converts an expense
+func toExpense(expense calculator.ExpenseInput) Expense {
+	return Expense{
+		Description: expense.Description,
+		Amount:      expense.Amount,
+		Currency:    expense.Currency,
+		Markup:      expense.Markup,
+		Receipt:     expense.Receipt,
+	}
+}
+
+// toResult converts a calculation result
+func toResult(result *calculator.CalculationResult) *Result {
+	r := &Result{
+		TotalWeeks:    result.TotalWeeks,
+		TotalDays:     result.TotalDays,
+		TotalHours:    result.TotalHours,
+		TotalTime:     result.TotalTime,
+		BilledHours:   result.BilledHours,
+		LaborAmount:   result.LaborAmount,
+		ItemsAmount:   result.ItemsAmount,
+		ExpenseTotal:  result.ExpenseTotal,
+		LaborTax:      result.LaborTax,
+		ExpenseTax:    result.ExpenseTax,
+		TotalAmount:   result.TotalAmount,
+		Currency:      result.Currency,
+		HourlyRate:    result.HourlyRate,
+		WeeksStrategy: result.WeeksStrategy,
+		WeeksPerMonth: result.WeeksPerMonth,
+	}
+	for _, monthInfo := range result.MonthDetails {
+		r.Months = append(r.Months, toMonth(monthInfo))
+	}
+	for _, adjustment := range result.Adjustments {
+		r.Adjustments = append(r.Adjustments, Adjustment{
+			Description: adjustment.Description,
+			Hours:       adjustment.Hours,
+			Amount:      adjustment.Amount,
+			Project:     adjustment.Project,
+			Person:      adjustment.Person,
+			Invoice:     adjustment.Invoice,
+		})
+	}
+	for _, item := range result.LineItems {
+		r.LineItems = append(r.LineItems, LineItem{
+			Type:        item.Type,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+			Project:     item.Project,
+			Person:      item.Person,
+		})
+	}
+	for _, expense := range result.Expenses {
+		r.Expenses = append(r.Expenses, ExpenseLine{
+			Description:      expense.Description,
+			Receipt:          expense.Receipt,
+			OriginalAmount:   expense.OriginalAmount,
+			OriginalCurrency: expense.OriginalCurrency,
+			ExchangeRate:     expense.ExchangeRate,
+			Amount:           expense.Amount,
+			Markup:           expense.Markup,
+			MarkupAmount:     expense.MarkupAmount,
+			Total:            expense.Total,
+			Project:          expense.Project,
+			Person:           expense.Person,
+		})
+	}
+	if result.Retainer != nil {
+		r.Retainer = &RetainerSummary{
+			OpeningHours:   result.Retainer.OpeningHours,
+			DrawnHours:     result.Retainer.DrawnHours,
+			ExpiredHours:   result.Retainer.ExpiredHours,
+			RemainingHours: result.Retainer.RemainingHours,
+			OverageHours:   result.Retainer.OverageHours,
+			OverageRate:    result.Retainer.OverageRate,
+			OverageAmount:  result.Retainer.OverageAmount,
+		}
+	}
+	for _, project := range result.Projects {
+		r.Projects = append(r.Projects, ProjectResult{
+			Project:      project.Project,
+			Currency:     project.Currency,
+			ExchangeRate: project.ExchangeRate,
+			HourlyRate:   project.HourlyRate,
+			MixedRates:   project.MixedRates,
+			Subtotals:    toSubtotals(project.Subtotals),
+		})
+	}
+	for _, person := range result.People {
+		r.People = append(r.People, PersonResult{
+			Person:     person.Person,
+			Role:       person.Role,
+			HourlyRate: person.HourlyRate,
+			MixedRates: person.MixedRates,
+			Subtotals:  toSubtotals(person.Subtotals),
+		})
+	}
+	for _, part := range result.Parts {
+		r.Parts = append(r.Parts, PartResult{
+			Project:    part.Project,
+			Person:     part.Person,
+			HourlyRate: part.HourlyRate,
+			MixedRates: part.MixedRates,
+			Subtotals:  toSubtotals(part.Subtotals),
+		})
+	}
+	return r
+}
+
+// fromResult converts a result back into a calculation result, to render
+// it with the engine's formatters
+func fromResult(r *Result) *calculator.CalculationResult {
+	result := &calculator.CalculationResult{
+		TotalWeeks:    r.TotalWeeks,
+		TotalDays:     r.TotalDays,
+		TotalHours:    r.TotalHours,
+		TotalTime:     r.TotalTime,
+		BilledHours:   r.BilledHours,
+		LaborAmount:   r.LaborAmount,
+		ItemsAmount:   r.ItemsAmount,
+		ExpenseTotal:  r.ExpenseTotal,
+		LaborTax:      r.LaborTax,
+		ExpenseTax:    r.ExpenseTax,
+		TotalAmount:   r.TotalAmount,
+		Currency:      r.Currency,
+		HourlyRate:    r.HourlyRate,
+		WeeksStrategy: r.WeeksStrategy,
+		WeeksPerMonth: r.WeeksPerMonth,
+	}
+	for _, month := range r.Months {
+		result.MonthDetails = append(result.MonthDetails, fromMonth(month))
+	}
+	for _, adjustment := range r.Adjustments {
+		result.Adjustments = append(result.Adjustments, calculator.Adjustment{
+			Description: adjustment.Description,
+			Hours:       adjustment.Hours,
+			Amount:      adjustment.Amount,
+			Project:     adjustment.Project,
+			Person:      adjustment.Person,
+			Invoice:     adjustment.Invoice,
+		})
+	}
+	for _, item := range r.LineItems {
+		result.LineItems = append(result.LineItems, calculator.LineItem{
+			Type:        item.Type,
+			Description: item.Description,
+			Quantity:    item.Quantity,
+			UnitPrice:   item.UnitPrice,
+			Amount:      item.Amount,
+			Project:     item.Project,
+			Person:      item.Person,
+		})
+	}
+	for _, expense := range r.Expenses {
+		result.Expenses = append(result.Expenses, calculator.ExpenseLine{
+			Description:      expense.Description,
+			Receipt:          expense.Receipt,
+			OriginalAmount:   expense.OriginalAmount,
+			OriginalCurrency: expense.OriginalCurrency,
+			ExchangeRate:     expense.ExchangeRate,
+			Amount:           expense.Amount,
+			Markup:           expense.Markup,
+			MarkupAmount:     expense.MarkupAmount,
+			Total:            expense.Total,
+			Project:          expense.Project,
+			Person:           expense.Person,
+		})
+	}
+	if r.Retainer != nil {
+		result.Retainer = &calculator.RetainerSummary{
+			OpeningHours:   r.Retainer.OpeningHours,
+			DrawnHours:     r.Retainer.DrawnHours,
+			ExpiredHours:   r.Retainer.ExpiredHours,
+			RemainingHours: r.Retainer.RemainingHours,
+			OverageHours:   r.Retainer.OverageHours,
+			OverageRate:    r.Retainer.OverageRate,
+			OverageAmount:  r.Retainer.OverageAmount,
+		}
+	}
+	for _, project := range r.Projects {
+		result.Projects = append(result.Projects, calculator.ProjectResult{
+			Project:      project.Project,
+			Currency:     project.Currency,
+			ExchangeRate: project.ExchangeRate,
+			HourlyRate:   project.HourlyRate,
+			MixedRates:   project.MixedRates,
+			Subtotals:    fromSubtotals(project.Subtotals),
+		})
+	}
+	for _, person := range r.People {
+		result.People = append(result.People, calculator.PersonResult{
+			Person:     person.Person,
+			Role:       person.Role,
+			HourlyRate: person.HourlyRate,
+			MixedRates: person.MixedRates,
+			Subtotals:  fromSubtotals(person.Subtotals),
+		})
+	}
+	for _, part := range r.Parts {
+		result.Parts = append(result.Parts, calculator.PartResult{
+			Project:    part.Project,
+			Person:     part.Person,
+			HourlyRate: part.HourlyRate,
+			MixedRates: part.MixedRates,
+			Subtotals:  fromSubtotals(part.Subtotals),
+		})
+	}
+	return result
+}
+
+// toMonth converts the billed time of a month
+func toMonth(monthInfo calculator.MonthInfo) Month {
+	return Month{
+		Input:      monthInfo.Input,
+		Days:       monthInfo.Days,
+		Year:       monthInfo.Year,
+		Month:      monthInfo.Month,
+		Hours:      monthInfo.Hours,
+		HourlyRate: monthInfo.HourlyRate,
+		Amount:     monthInfo.Amount,
+		From:       monthInfo.From,
+		To:         monthInfo.To,
+		Since:      monthInfo.Since,
+		Until:      monthInfo.Until,
+		Project:    monthInfo.Project,
+		Person:     monthInfo.Person,
+	}
+}
+
+// fromMonth converts a month back into calculator month information
+func fromMonth(month Month) calculator.MonthInfo {
+	return calculator.MonthInfo{
+		Input:      month.Input,
+		Days:       month.Days,
+		Year:       month.Year,
+		Month:      month.Month,
+		Hours:      month.Hours,
+		HourlyRate: month.HourlyRate,
+		Amount:     month.Amount,
+		From:       month.From,
+		To:         month.To,
+		Since:      month.Since,
+		Until:      month.Until,
+		Project:    month.Project,
+		Person:     month.Person,
+	}
+}
+
+// toSubtotals converts the totals of a part of a result
+func toSubtotals(subtotals calculator.Subtotals) Subtotals {
+	return Subtotals{
+		TotalWeeks:   subtotals.TotalWeeks,
+		TotalDays:    subtotals.TotalDays,
+		TotalHours:   subtotals.TotalHours,
+		TotalTime:    subtotals.TotalTime,
+		BilledHours:  subtotals.BilledHours,
+		LaborAmount:  subtotals.LaborAmount,
+		ItemsAmount:  subtotals.ItemsAmount,
+		ExpenseTotal: subtotals.ExpenseTotal,
+		LaborTax:     subtotals.LaborTax,
+		ExpenseTax:   subtotals.ExpenseTax,
+		Subtotal:     subtotals.Subtotal,
+	}
+}
+
+// fromSubtotals converts subtotals back into calculator subtotals
+func fromSubtotals(subtotals Subtotals) calculator.Subtotals {
+	return calculator.Subtotals{
+		TotalWeeks:   subtotals.TotalWeeks,
+		TotalDays:    subtotals.TotalDays,
+		TotalHours:   subtotals.TotalHours,
+		TotalTime:    subtotals.TotalTime,
+		BilledHours:  subtotals.BilledHours,
+		LaborAmount:  subtotals.LaborAmount,
+		ItemsAmount:  subtotals.ItemsAmount,
+		ExpenseTotal: subtotals.ExpenseTotal,
+		LaborTax:     subtotals.LaborTax,
+		ExpenseTax:   subtotals.ExpenseTax,
+		Subtotal:     subtotals.Subtotal,
+	}
+}
+
+// toRetainerBank converts the balance of a retainer bank
+func toRetainerBank(state *calculator.RetainerState) *RetainerBank {
+	if state == nil {
+		return nil
+	}
+	bank := &RetainerBank{Period: state.Period}
+	for _, lot := range state.Lots {
+		bank.Lots = append(bank.Lots, RetainerLot{Period: lot.Period, Hours: lot.Hours})
+	}
+	return bank
+}
+
+// fromRetainerBank converts a retainer bank back into calculator state
+func fromRetainerBank(bank *RetainerBank) *calculator.RetainerState {
+	if bank == nil {
+		return nil
+	}
+	state := &calculator.RetainerState{Period: bank.Period}
+	for _, lot := range bank.Lots {
+		state.Lots = append(state.Lots, calculator.RetainerLot{Period: lot.Period, Hours: lot.Hours})
+	}
+	return state
+}
+
+// toIndexation converts an index adjustment
+func toIndexation(adjustment *config.IndexAdjustment) *Indexation {
+	if adjustment == nil {
+		return nil
+	}
+	return &Indexation{
+		Name:      adjustment.Name,
+		Base:      adjustment.Base,
+		Month:     adjustment.Month,
+		BaseValue: adjustment.BaseValue,
+		Value:     adjustment.Value,
+		Periods:   adjustment.Periods,
+		Factor:    adjustment.Factor,
+	}
+}
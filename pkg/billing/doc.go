@@ -0,0 +1,18 @@
+// Package billing is the supported Go API of billctl. It calculates billable
+// hours and amounts with the same engine as the billctl command, so other
+// services can import it instead of shelling out to the CLI.
+//
+//	calc, err := billing.New(billing.WithRate(25, billing.Hourly), billing.WithCurrency("EUR"))
+//	if err != nil {
+//		return err
+//	}
+//	result, err := calc.Calculate(ctx, billing.Input{Months: []string{"2026-09"}})
+//
+// # Compatibility
+//
+// The package follows semantic versioning together with the billctl module.
+// Within a major version exported identifiers are never removed or renamed,
+// function signatures do not change, new struct fields are only added, and
+// the JSON names of result fields stay the same. Everything under internal/
+// may change at any time.
+package billing
@@ -0,0 +1,57 @@
+package billing
+
+import (
+	"errors"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// Error kinds. Every error returned by the package matches one of them with
+// errors.Is.
+var (
+	ErrInvalidConfig = errors.New("invalid billing configuration")
+	ErrInvalidInput  = errors.New("invalid billing input")
+)
+
+// Specific input problems, matched with errors.Is alongside ErrInvalidInput
+var (
+	ErrInvalidMonth     = calculator.ErrInvalidMonth
+	ErrNegativeQuantity = calculator.ErrNegativeQuantity
+)
+
+// Error is an error returned by the package. Kind is ErrInvalidConfig or
+// ErrInvalidInput; Err holds the underlying cause.
+type Error struct {
+	Kind error
+	Err  error
+}
+
+// Error returns the message of the underlying cause
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the kind and the cause so both match errors.Is
+func (e *Error) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+// configError wraps an error as an invalid configuration
+func configError(err error) error {
+	return &Error{Kind: ErrInvalidConfig, Err: err}
+}
+
+// inputError wraps an error as invalid input
+func inputError(err error) error {
+	return &Error{Kind: ErrInvalidInput, Err: err}
+}
+
+// calculationError wraps an error of a calculation, which comes from the
+// configuration when the input is billed in a way it does not support
+func calculationError(err error) error {
+	if errors.Is(err, config.ErrConfig) {
+		return configError(err)
+	}
+	return inputError(err)
+}
@@ -0,0 +1,116 @@
+package billing_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/develpudu/billctl/pkg/billing"
+)
+
+func Example() {
+	calc, err := billing.New(
+		billing.WithRate(25, billing.Hourly),
+		billing.WithCurrency("EUR"),
+	)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	result, err := calc.Calculate(context.Background(), billing.Input{
+		Months: []string{"2024-02"},
+		Hours:  []float64{4},
+	})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("%.0f hours: %s %.2f\n", result.TotalTime, result.Currency, result.TotalAmount)
+	// Output: 236 hours: EUR 5900.00
+}
+
+func ExampleCalculator_Calculate_items() {
+	calc, _ := billing.New(billing.WithRate(200, billing.Daily))
+
+	result, _ := calc.Calculate(context.Background(), billing.Input{
+		Days: []int{3},
+		Items: []billing.Item{
+			{Type: billing.ItemFixed, Description: "Setup", Amount: 500},
+			{Type: billing.ItemMilestone, Description: "Delivery", Amount: 10000, Percent: 30},
+		},
+		Currency: "USD",
+	})
+	for _, item := range result.LineItems {
+		fmt.Printf("%s: %.2f\n", item.Description, item.Amount)
+	}
+	fmt.Printf("Total: %s %.2f\n", result.Currency, result.TotalAmount)
+	// Output:
+	// Setup: 500.00
+	// Delivery: 3000.00
+	// Total: USD 4100.00
+}
+
+func ExampleCalculator_Calculate_relativePeriod() {
+	today := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	calc, _ := billing.New(billing.WithToday(today))
+
+	result, _ := calc.Calculate(context.Background(), billing.Input{Months: []string{"last-month"}})
+	fmt.Printf("%s: %d days\n", result.Months[0].Input, result.Months[0].Days)
+	// Output: 2026-09: 30 days
+}
+
+func ExampleCalculator_CalculateInvoice() {
+	dir, _ := os.MkdirTemp("", "billing")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "billctl.json")
+	os.WriteFile(path, []byte(`{"profiles": {"acme": {
+		"rate_anchor": "hourly", "rate_amount": 40,
+		"projects": {"web": {"hourly_rate": 50}, "app": {}}}}}`), 0o644)
+
+	calc, _ := billing.New(billing.WithProfile(path, "acme"))
+	result, _ := calc.CalculateInvoice(context.Background(), "EUR",
+		billing.Input{Project: "web", Hours: []float64{10}},
+		billing.Input{Project: "app", Days: []int{2}},
+	)
+	for _, project := range result.Projects {
+		fmt.Printf("%s: %.0f hours, %.2f\n", project.Project, project.TotalTime, project.Subtotal)
+	}
+	fmt.Printf("Total: %s %.2f\n", result.Currency, result.TotalAmount)
+	// Output:
+	// web: 10 hours, 500.00
+	// app: 16 hours, 640.00
+	// Total: EUR 1140.00
+}
+
+func ExampleCalculator_Month() {
+	calc, _ := billing.New()
+
+	month, _ := calc.Month(context.Background(), "2024-02")
+	fmt.Printf("%d days, %.0f hours, %.2f\n", month.Days, month.Hours, month.Amount)
+	// Output: 29 days, 232 hours, 3190.00
+}
+
+func ExampleCalculator_Rates() {
+	calc, _ := billing.New(billing.WithRate(48000, billing.Annual))
+
+	rates := calc.Rates("USD")
+	fmt.Printf("%s %.2f/h, %.2f/month\n", rates.Currency, rates.Hourly, rates.Monthly)
+	// Output: USD 25.00/h, 4000.00/month
+}
+
+func ExampleError() {
+	calc, _ := billing.New()
+
+	_, err := calc.Calculate(context.Background(), billing.Input{Months: []string{"2024-13"}})
+	fmt.Println(errors.Is(err, billing.ErrInvalidInput), errors.Is(err, billing.ErrInvalidMonth))
+
+	_, err = billing.New(billing.WithRate(-1, billing.Hourly))
+	fmt.Println(errors.Is(err, billing.ErrInvalidConfig))
+	// Output:
+	// true true
+	// true
+}
@@ -0,0 +1,69 @@
+package billing
+
+import (
+	"strings"
+
+	"github.com/develpudu/billctl/internal/calculator"
+)
+
+// Output formats accepted by Format, FormatRates and FormatMonths
+const (
+	FormatText = calculator.OutputText // the report printed by billctl
+	FormatJSON = calculator.OutputJSON // indented JSON of the result
+	FormatCSV  = calculator.OutputCSV  // one row per billed line
+)
+
+// Format renders a result as billctl prints it with --output
+func (c *Calculator) Format(result *Result, format string) (string, error) {
+	output, err := c.engine().Format(fromResult(result), format)
+	if err != nil {
+		return "", inputError(err)
+	}
+	return output, nil
+}
+
+// FormatRates renders the rate table in the given currency, or in the
+// calculator's currency when it is empty
+func (c *Calculator) FormatRates(currency, format string) (string, error) {
+	calc := c.engine()
+	currency = c.currency(currency)
+	switch format {
+	case FormatText:
+		return calc.FormatRates(currency), nil
+	case FormatCSV:
+		return calc.FormatRatesCSV(currency), nil
+	case FormatJSON:
+		return calculator.FormatJSON(c.Rates(currency))
+	default:
+		return "", inputError(calculator.ValidateOutput(format))
+	}
+}
+
+// FormatMonths renders whole months returned by Month in the given
+// currency, or in the calculator's currency when it is empty
+func (c *Calculator) FormatMonths(months []Month, currency, format string) (string, error) {
+	calc := c.engine()
+	currency = c.currency(currency)
+	var summaries []calculator.MonthInfo
+	for _, month := range months {
+		summaries = append(summaries, fromMonth(month))
+	}
+
+	switch format {
+	case FormatText:
+		var output strings.Builder
+		for _, monthInfo := range summaries {
+			output.WriteString(calc.FormatMonthSummary(monthInfo, currency) + "\n")
+		}
+		return output.String(), nil
+	case FormatCSV:
+		return calculator.FormatMonthsCSV(summaries, currency), nil
+	case FormatJSON:
+		return calculator.FormatJSON(struct {
+			Currency string  `json:"currency"`
+			Months   []Month `json:"months"`
+		}{currency, months})
+	default:
+		return "", inputError(calculator.ValidateOutput(format))
+	}
+}
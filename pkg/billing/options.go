@@ -0,0 +1,171 @@
+package billing
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/develpudu/billctl/internal/calculator"
+	"github.com/develpudu/billctl/internal/config"
+)
+
+// Rate units accepted by WithRate
+const (
+	Hourly  = config.AnchorHourly
+	Daily   = config.AnchorDaily
+	Weekly  = config.AnchorWeekly
+	Monthly = config.AnchorMonthly
+	Annual  = config.AnchorAnnual
+)
+
+// Weeks-per-month strategies accepted by WithWeeksStrategy
+const (
+	WeeksFixed    = config.WeeksFixed    // a fixed number of weeks in every month
+	WeeksAverage  = config.WeeksAverage  // 52/12 weeks in every month
+	WeeksCalendar = config.WeeksCalendar // calendar days of the billed month / 7
+	WeeksWorking  = config.WeeksWorking  // working days of the billed month
+)
+
+// Clock supplies the current date used to resolve relative periods
+type Clock interface {
+	Now() time.Time
+}
+
+// Option configures a Calculator
+type Option func(c *Calculator) error
+
+// WithProfile loads a named profile from a billctl JSON config file. An empty
+// name selects the file's default profile. Options applied after it adjust
+// the loaded profile.
+func WithProfile(path, name string) Option {
+	return func(c *Calculator) error {
+		file, err := config.LoadFile(path)
+		if err != nil {
+			return err
+		}
+		cfg, err := file.Profile(name)
+		if err != nil {
+			return err
+		}
+		c.config = cfg
+		return nil
+	}
+}
+
+// WithConfig uses a billing configuration already loaded and validated by
+// billctl itself, which keeps it under internal/config. Other programs load
+// profiles with WithProfile. Options applied after it adjust cfg in place.
+func WithConfig(cfg *config.BillingConfig) Option {
+	return func(c *Calculator) error {
+		if cfg == nil {
+			return fmt.Errorf("config cannot be nil")
+		}
+		c.config = cfg
+		return nil
+	}
+}
+
+// WithRate sets the contract rate as an amount per unit (Hourly, Daily,
+// Weekly, Monthly or Annual)
+func WithRate(amount float64, unit string) Option {
+	return func(c *Calculator) error {
+		return c.config.SetRate(unit, amount)
+	}
+}
+
+// WithSchedule sets the weekly hours and the hours worked per day
+func WithSchedule(weeklyHours, hoursPerDay int) Option {
+	return func(c *Calculator) error {
+		if err := c.config.SetWeeklyHours(weeklyHours); err != nil {
+			return err
+		}
+		return c.config.SetHoursPerDay(hoursPerDay)
+	}
+}
+
+// WithWeeksStrategy sets how the weeks of a month are derived
+func WithWeeksStrategy(strategy string) Option {
+	return func(c *Calculator) error {
+		return c.config.SetWeeksStrategy(strategy)
+	}
+}
+
+// WithCurrency sets the currency used when Input.Currency is empty
+func WithCurrency(currency string) Option {
+	return func(c *Calculator) error {
+		if currency == "" {
+			return fmt.Errorf("currency cannot be empty")
+		}
+		c.config.DefaultCurrency = currency
+		return nil
+	}
+}
+
+// WithTaxRate sets the tax percentages applied to labor and fees and to
+// expenses
+func WithTaxRate(labor, expenses float64) Option {
+	return func(c *Calculator) error {
+		c.config.TaxRate = labor
+		c.config.ExpenseTaxRate = expenses
+		return nil
+	}
+}
+
+// WithExchangeRate sets the rate converting an amount in one currency into
+// another
+func WithExchangeRate(from, to string, rate float64) Option {
+	return func(c *Calculator) error {
+		return c.config.SetExchangeRate(from, to, rate)
+	}
+}
+
+// WithClock sets the clock used to resolve relative periods such as
+// last-month or ytd
+func WithClock(clock Clock) Option {
+	return func(c *Calculator) error {
+		if clock == nil {
+			return fmt.Errorf("clock cannot be nil")
+		}
+		c.clock = clock
+		return nil
+	}
+}
+
+// WithLogger sets the logger that traces each step of a calculation at
+// debug level. Calculators log to slog.Default otherwise.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *Calculator) error {
+		if logger == nil {
+			return fmt.Errorf("logger cannot be nil")
+		}
+		c.logger = logger
+		return nil
+	}
+}
+
+// WithRetainerBank starts the prepaid hour bank of the profile's retainer
+// from the balance left by an earlier calculation (see Result.RetainerBank)
+// instead of a full bank
+func WithRetainerBank(bank *RetainerBank) Option {
+	return func(c *Calculator) error {
+		state := fromRetainerBank(bank)
+		if err := calculator.NewCalculator(c.config).SetRetainerState(state); err != nil {
+			return err
+		}
+		c.retainer = state
+		return nil
+	}
+}
+
+// WithToday fixes the current date used to resolve relative periods
+func WithToday(today time.Time) Option {
+	return WithClock(fixedClock(today))
+}
+
+// fixedClock always reports the same date
+type fixedClock time.Time
+
+// Now returns the fixed date
+func (f fixedClock) Now() time.Time {
+	return time.Time(f)
+}
@@ -0,0 +1,42 @@
+package billing
+
+import (
+	"io"
+
+	"github.com/develpudu/billctl/internal/calculator"
+)
+
+// ParseItem parses a line item given in the format of the billctl --fixed,
+// --milestone and --recurring flags: DESCRIPTION:AMOUNT, or
+// DESCRIPTION:PERCENT:TOTAL for milestones
+func ParseItem(itemType, value string) (Item, error) {
+	item, err := calculator.ParseItem(itemType, value)
+	if err != nil {
+		return Item{}, inputError(err)
+	}
+	return toItem(item), nil
+}
+
+// ParseExpense parses an expense given in the format of the billctl
+// --expense flag: DESCRIPTION:AMOUNT[:CURRENCY][:markup=PERCENT][:receipt=FILE]
+func ParseExpense(value string) (Expense, error) {
+	expense, err := calculator.ParseExpense(value)
+	if err != nil {
+		return Expense{}, inputError(err)
+	}
+	return toExpense(expense), nil
+}
+
+// ReadExpenses reads expenses from CSV in the format of the billctl
+// --expenses file
+func ReadExpenses(r io.Reader) ([]Expense, error) {
+	inputs, err := calculator.ParseExpensesCSV(r)
+	if err != nil {
+		return nil, inputError(err)
+	}
+	var expenses []Expense
+	for _, expense := range inputs {
+		expenses = append(expenses, toExpense(expense))
+	}
+	return expenses, nil
+}
@@ -0,0 +1,217 @@
+package billing
+
+// Input is the work to bill. Every field is optional; the amounts of all
+// of them are added up.
+type Input struct {
+	Hours       []float64 `json:"hours,omitempty"`
+	Days        []int     `json:"days,omitempty"`
+	Weeks       []int     `json:"weeks,omitempty"`
+	Months      []string  `json:"months,omitempty"`       // periods in any billctl -m format (2026-09, 2026-Q3, last-month...)
+	From        string    `json:"from,omitempty"`         // first date of a date range (YYYY-MM-DD)
+	To          string    `json:"to,omitempty"`           // inclusive end of the date range
+	ToExclusive bool      `json:"to_exclusive,omitempty"` // do not bill the To date itself
+	Items       []Item    `json:"items,omitempty"`
+	Expenses    []Expense `json:"expenses,omitempty"`
+	Currency    string    `json:"currency,omitempty"` // empty uses the calculator's currency
+	Project     string    `json:"project,omitempty"`  // project the input is billed to (see CalculateInvoice)
+	Person      string    `json:"person,omitempty"`   // team member who did the work (see CalculateInvoice)
+}
+
+// Item types
+const (
+	ItemFixed     = "fixed"
+	ItemMilestone = "milestone"
+	ItemRecurring = "recurring"
+)
+
+// Item is a non-hourly charge
+type Item struct {
+	Type        string  `json:"type"` // ItemFixed, ItemMilestone or ItemRecurring
+	Description string  `json:"description"`
+	Amount      float64 `json:"amount"`            // fixed fee, project total for milestones, or monthly fee
+	Percent     float64 `json:"percent,omitempty"` // share of the project total billed by a milestone
+}
+
+// Expense is a reimbursable expense
+type Expense struct {
+	Description string   `json:"description"`
+	Amount      float64  `json:"amount"`
+	Currency    string   `json:"currency,omitempty"` // empty means the invoice currency
+	Markup      *float64 `json:"markup,omitempty"`   // percentage; nil uses the configured markup
+	Receipt     string   `json:"receipt,omitempty"`
+}
+
+// Result is the breakdown and total of a calculation
+type Result struct {
+	Months        []Month          `json:"month_details,omitempty"`
+	TotalWeeks    int              `json:"total_weeks"`
+	TotalDays     int              `json:"total_days"`
+	TotalHours    float64          `json:"total_hours"`
+	TotalTime     float64          `json:"total_time"`
+	BilledHours   float64          `json:"billed_hours"`
+	Adjustments   []Adjustment     `json:"adjustments,omitempty"`
+	LineItems     []LineItem       `json:"line_items,omitempty"`
+	Expenses      []ExpenseLine    `json:"expenses,omitempty"`
+	LaborAmount   float64          `json:"labor_amount"`
+	ItemsAmount   float64          `json:"items_amount"`
+	ExpenseTotal  float64          `json:"expense_total"`
+	LaborTax      float64          `json:"labor_tax"`
+	ExpenseTax    float64          `json:"expense_tax"`
+	TotalAmount   float64          `json:"total_amount"`
+	Currency      string           `json:"currency"`
+	HourlyRate    float64          `json:"hourly_rate"`
+	WeeksStrategy string           `json:"weeks_strategy"`
+	WeeksPerMonth float64          `json:"weeks_per_month"`
+	Retainer      *RetainerSummary `json:"retainer,omitempty"`
+	Projects      []ProjectResult  `json:"projects,omitempty"`
+	People        []PersonResult   `json:"people,omitempty"`
+	Parts         []PartResult     `json:"parts,omitempty"`
+
+	// RetainerBank is the prepaid hour bank left after the calculation, to
+	// start the next one from with WithRetainerBank. It is nil without a
+	// retainer.
+	RetainerBank *RetainerBank `json:"-"`
+}
+
+// Subtotals are the totals of the part of a result billed to one project,
+// team member or both
+type Subtotals struct {
+	TotalWeeks   int     `json:"total_weeks"`
+	TotalDays    int     `json:"total_days"`
+	TotalHours   float64 `json:"total_hours"`
+	TotalTime    float64 `json:"total_time"`
+	BilledHours  float64 `json:"billed_hours"`
+	LaborAmount  float64 `json:"labor_amount"`
+	ItemsAmount  float64 `json:"items_amount"`
+	ExpenseTotal float64 `json:"expense_total"`
+	LaborTax     float64 `json:"labor_tax"`
+	ExpenseTax   float64 `json:"expense_tax"`
+	Subtotal     float64 `json:"subtotal"` // labor, items and expenses before taxes
+}
+
+// ProjectResult is the part of a result billed to one project
+type ProjectResult struct {
+	Project      string  `json:"project"`
+	Currency     string  `json:"currency,omitempty"`      // currency the project's rate is quoted in
+	ExchangeRate float64 `json:"exchange_rate,omitempty"` // converts the project's rate into the invoice currency
+	HourlyRate   float64 `json:"hourly_rate"`             // in the invoice currency, 0 with mixed rates
+	MixedRates   bool    `json:"mixed_rates,omitempty"`   // billed at several rates, listed in the parts
+	Subtotals
+}
+
+// PersonResult is the part of a result billed for one team member
+type PersonResult struct {
+	Person     string  `json:"person"`
+	Role       string  `json:"role,omitempty"`
+	HourlyRate float64 `json:"hourly_rate"`           // 0 with mixed rates
+	MixedRates bool    `json:"mixed_rates,omitempty"` // billed at several rates, listed in the parts
+	Subtotals
+}
+
+// PartResult is the part of a result billed to one project and team member
+type PartResult struct {
+	Project    string  `json:"project,omitempty"`
+	Person     string  `json:"person,omitempty"`
+	HourlyRate float64 `json:"hourly_rate"` // in the invoice currency, 0 with mixed rates
+	MixedRates bool    `json:"mixed_rates,omitempty"`
+	Subtotals
+}
+
+// Month is the billed time of one month or date range segment
+type Month struct {
+	Input      string  `json:"input"`
+	Days       int     `json:"days"`
+	Year       int     `json:"year"`
+	Month      int     `json:"month"`
+	Hours      float64 `json:"hours"`
+	HourlyRate float64 `json:"hourly_rate"`
+	Amount     float64 `json:"amount"`
+	From       string  `json:"from,omitempty"`  // first billed date of a date range segment (YYYY-MM-DD)
+	To         string  `json:"to,omitempty"`    // last billed date of a date range segment
+	Since      string  `json:"since,omitempty"` // first date of a whole month billed in parts across a rate change
+	Until      string  `json:"until,omitempty"` // last date of that part
+	Project    string  `json:"project,omitempty"`
+	Person     string  `json:"person,omitempty"`
+}
+
+// Adjustment is a change to the billed hours made by the pricing rules
+type Adjustment struct {
+	Description string  `json:"description"`
+	Hours       float64 `json:"hours"`
+	Amount      float64 `json:"amount"`
+	Project     string  `json:"project,omitempty"`
+	Person      string  `json:"person,omitempty"`
+	Invoice     bool    `json:"invoice,omitempty"` // applies to the whole invoice, not to one project or person
+}
+
+// LineItem is a billed non-hourly charge
+type LineItem struct {
+	Type        string  `json:"type"`
+	Description string  `json:"description"`
+	Quantity    float64 `json:"quantity"`
+	UnitPrice   float64 `json:"unit_price"`
+	Amount      float64 `json:"amount"`
+	Project     string  `json:"project,omitempty"`
+	Person      string  `json:"person,omitempty"`
+}
+
+// ExpenseLine is a billed expense converted into the invoice currency
+type ExpenseLine struct {
+	Description      string  `json:"description"`
+	Receipt          string  `json:"receipt,omitempty"`
+	OriginalAmount   float64 `json:"original_amount"`
+	OriginalCurrency string  `json:"original_currency"`
+	ExchangeRate     float64 `json:"exchange_rate"`
+	Amount           float64 `json:"amount"`
+	Markup           float64 `json:"markup"`
+	MarkupAmount     float64 `json:"markup_amount"`
+	Total            float64 `json:"total"`
+	Project          string  `json:"project,omitempty"`
+	Person           string  `json:"person,omitempty"`
+}
+
+// RetainerSummary reports how a calculation drew on a prepaid hour bank
+type RetainerSummary struct {
+	OpeningHours   float64 `json:"opening_hours"`
+	DrawnHours     float64 `json:"drawn_hours"`
+	ExpiredHours   float64 `json:"expired_hours"`
+	RemainingHours float64 `json:"remaining_hours"`
+	OverageHours   float64 `json:"overage_hours"`
+	OverageRate    float64 `json:"overage_rate"`
+	OverageAmount  float64 `json:"overage_amount"`
+}
+
+// RetainerBank is the balance of a prepaid hour bank carried from one
+// calculation to the next
+type RetainerBank struct {
+	Period string        `json:"period"` // last month drawn on (YYYY-MM)
+	Lots   []RetainerLot `json:"lots"`
+}
+
+// RetainerLot is the hours of a bank refill still available
+type RetainerLot struct {
+	Period string  `json:"period"` // month of the refill (YYYY-MM)
+	Hours  float64 `json:"hours"`
+}
+
+// Rates is the rate table of a calculator
+type Rates struct {
+	Currency   string      `json:"currency"`
+	Hourly     float64     `json:"hourly"`
+	Daily      float64     `json:"daily"`
+	Weekly     float64     `json:"weekly"`
+	Monthly    float64     `json:"monthly"`
+	Annual     float64     `json:"annual"`
+	Indexation *Indexation `json:"indexation,omitempty"` // nil when the rates are not indexed
+}
+
+// Indexation is the index adjustment applied to the rates of a month
+type Indexation struct {
+	Name      string  `json:"name,omitempty"`
+	Base      string  `json:"base"`              // month the rate is expressed in
+	Month     string  `json:"month"`             // month of the index value used
+	BaseValue float64 `json:"base_value"`        // index value of the base month (100 for a fixed increase)
+	Value     float64 `json:"value"`             // index value applied
+	Periods   int     `json:"periods,omitempty"` // periods of a fixed increase
+	Factor    float64 `json:"factor"`            // Value / BaseValue
+}
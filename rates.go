@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/develpudu/billctl/internal/calculator"
+
+	"github.com/spf13/cobra"
+)
+
+var ratesCmd = &cobra.Command{
+	Use:   "rates",
+	Short: "Show the rate table",
+	Long: `Show the hourly, daily, weekly, monthly and annual rates of a profile,
+derived from its rate anchor and weeks-per-month strategy. Rates follow the
+profile's rate history and indexation; --as-of shows them for another month,
+with the index values used.
+
+Examples:
+  billctl rates
+  billctl rates --client acme --currency=EUR
+  billctl rates --client acme --as-of 2026-09
+  billctl rates --rate 25/h -o json`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRates()
+	},
+}
+
+// ratesAsOf is the month the rate table is shown for (default: the current one)
+var ratesAsOf string
+
+// runRates prints the rate table in the --output format
+func runRates() error {
+	cfg, problems, err := resolveConfig()
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return &calculator.ValidationError{Errors: problems}
+	}
+	clock, err := buildClock()
+	if err != nil {
+		return err
+	}
+	if ratesAsOf != "" {
+		month, err := time.Parse("2006-01", ratesAsOf)
+		if err != nil {
+			return usageError{fmt.Errorf("invalid --as-of month: %s (use YYYY-MM)", ratesAsOf)}
+		}
+		clock = calculator.FixedClock{Time: month}
+	}
+	calc, err := newBilling(cfg, clock, nil)
+	if err != nil {
+		return err
+	}
+
+	output, err := calc.FormatRates(currency, outputMode)
+	if err != nil {
+		return err
+	}
+	fmt.Print(output)
+	return nil
+}
+
+func init() {
+	addProfileFlags(ratesCmd)
+	addCurrencyFlag(ratesCmd)
+	ratesCmd.Flags().StringVar(&ratesAsOf, "as-of", "", "Show the rates in effect in this month (YYYY-MM)")
+	ratesCmd.RegisterFlagCompletionFunc("as-of", completeMonths)
+}
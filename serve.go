@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/develpudu/billctl/internal/server"
+
+	"github.com/spf13/cobra"
+)
+
+// Serve command flags
+var serveAddr string
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the calculator as an HTTP API",
+	Long: `Expose the calculator over HTTP:
+
+  POST /v1/calculate        Calculate a billing amount (JSON body)
+  GET  /v1/rates            Rate table (?profile=&currency=)
+  GET  /v1/months/YYYY-MM   Hours and amount of a month (?profile=&currency=)
+  GET  /openapi.json        OpenAPI document
+
+Profiles are read from the same config file as the CLI.`,
+	Args: usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		file, err := loadConfigFile()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		clock, err := buildClock()
+		if err != nil {
+			return err
+		}
+		check, err := policyCheck()
+		if err != nil {
+			return fmt.Errorf("policy error: %v", err)
+		}
+
+		api := &server.Server{Profile: profileResolver(file), Clock: clock, Check: check}
+		httpServer := &http.Server{
+			Addr:              serveAddr,
+			Handler:           api.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		errs := make(chan error, 1)
+		go func() {
+			fmt.Fprintf(os.Stderr, "Listening on %s\n", serveAddr)
+			errs <- httpServer.ListenAndServe()
+		}()
+
+		select {
+		case err := <-errs:
+			return err
+		case <-ctx.Done():
+		}
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVar(&serveAddr, "addr", ":8080", "Address to listen on")
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/develpudu/billctl/internal/calculator"
+
+	"github.com/spf13/cobra"
+)
+
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Show version information",
+	Args:  usageArgs(cobra.NoArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printVersion()
+	},
+}
+
+// printVersion prints the build information in the --output format
+func printVersion() error {
+	if outputMode == calculator.OutputJSON {
+		output, err := calculator.FormatJSON(struct {
+			Version   string `json:"version"`
+			BuildTime string `json:"build_time"`
+			GitCommit string `json:"git_commit"`
+			GoVersion string `json:"go_version"`
+		}{Version, BuildTime, GitCommit, "go1.21+"})
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+		return nil
+	}
+
+	fmt.Printf("Billctl v%s\n", Version)
+	fmt.Printf("Build Time: %s\n", BuildTime)
+	fmt.Printf("Git Commit: %s\n", GitCommit)
+	fmt.Printf("Go Version: %s\n", "go1.21+")
+	return nil
+}